@@ -1,4 +1,4 @@
-// Copyright (c) 2023-2024 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -18,6 +18,9 @@
 package config
 
 import (
+	"os"
+	"time"
+
 	"github.com/pkg/errors"
 )
 
@@ -26,10 +29,51 @@ type Config struct {
 	NodeName      string `default:"" desc:"Envvar from which to obtain the node ID" split_words:"true"`
 	PluginName    string `default:"csi.networkservicemesh.io" desc:"Plugin name to register" split_words:"true"`
 	SocketDir     string `default:"" desc:"Path to the NSM API socket directory" split_words:"true"`
+	SocketName    string `default:"nsm.io.sock" desc:"Name of the NSM API socket in the socket directory" split_words:"true"`
 	CSISocketPath string `default:"/nsm-csi/csi.sock" desc:"Path to the CSI socket" split_words:"true"`
-	Version       string `default:"undefined" desc:"Version"`
+	Version       string `default:"undefined" desc:"Version, the embedded build version if undefined"`
+	LogLevel      string `default:"INFO" desc:"Log level, SIGUSR1 switches it to TRACE and SIGUSR2 back" split_words:"true"`
+	LogFormat     string `default:"text" desc:"Log output format, text or json" split_words:"true"`
 	PprofEnabled  bool   `default:"false" desc:"is pprof enabled" split_words:"true"`
 	PprofListenOn string `default:"localhost:6060" desc:"pprof URL to ListenAndServe" split_words:"true"`
+
+	OpenTelemetryEndpoint string        `default:"otel-collector.observability.svc.cluster.local:4317" desc:"OpenTelemetry Collector Endpoint" split_words:"true"`
+	MetricsExportInterval time.Duration `default:"10s" desc:"interval between metrics exports" split_words:"true"`
+
+	CSISocketCheckInterval time.Duration            `default:"5s" desc:"How often the CSI socket is checked and recreated if removed" split_words:"true"`
+	RPCTimeout             time.Duration            `default:"0s" desc:"Deadline of the CSI RPCs without a method timeout, 0 disables it" split_words:"true"`
+	RPCMethodTimeouts      map[string]time.Duration `default:"" desc:"Deadlines of the CSI RPCs by method, e.g. NodePublishVolume:30s" split_words:"true"`
+	OperationTimeout       time.Duration            `default:"30s" desc:"Upper bound of the mount, unmount and volume health operations, 0 disables it" split_words:"true"`
+	SocketWaitTimeout      time.Duration            `default:"0s" desc:"How long publishing waits for the NSM API socket to accept connections, 0 disables it" split_words:"true"`
+	UnmountRetries         int                      `default:"5" desc:"Retries of an unmount failing because the target is busy" split_words:"true"`
+	UnmountRetryInterval   time.Duration            `default:"100ms" desc:"Delay before the first busy unmount retry, doubled for each further retry" split_words:"true"`
+	LazyUnmountEnabled     bool                     `default:"false" desc:"Enables the lazy unmount (MNT_DETACH) of a target still busy after the retries" split_words:"true"`
+
+	TargetDirMode os.FileMode `default:"0750" desc:"Permissions of the volume target directory" split_words:"true"`
+	TargetDirUID  int         `default:"-1" desc:"Owner UID of the volume target directory, -1 keeps the driver UID" split_words:"true"`
+	TargetDirGID  int         `default:"-1" desc:"Owner GID of the volume target directory, -1 keeps the driver GID" split_words:"true"`
+
+	PersistentVolumesEnabled bool `default:"false" desc:"Enables pre-provisioned persistent volumes staged with NodeStageVolume" split_words:"true"`
+	ControllerEnabled        bool `default:"false" desc:"Enables the controller service for generic ephemeral volumes" split_words:"true"`
+
+	TopologySegments  map[string]string `default:"" desc:"Topology segments reported by the node, e.g. topology.networkservicemesh.io/nsmgr:true" split_words:"true"`
+	TopologyLabelEnvs map[string]string `default:"" desc:"Topology segments read from env, e.g. node labels, as segment key:env name pairs" split_words:"true"`
+	MaxVolumesPerNode int64             `default:"0" desc:"Maximum number of volumes published on the node, 0 is unlimited" split_words:"true"`
+
+	AuditLogPath       string `default:"" desc:"Path to the audit log of the publish and unpublish attempts, disabled if empty" split_words:"true"`
+	AuditLogMaxSize    int64  `default:"10485760" desc:"Size in bytes the audit log is rotated at" split_words:"true"`
+	AuditLogMaxBackups int    `default:"3" desc:"Number of rotated audit log files kept" split_words:"true"`
+
+	EventsEnabled bool `default:"false" desc:"Enables Kubernetes Events on the pods for publish failures, requires the in-cluster config" split_words:"true"`
+
+	GCInterval       time.Duration `default:"0s" desc:"How often the orphaned volumes of the deleted pods are collected, 0 disables it" split_words:"true"`
+	GCDryRun         bool          `default:"false" desc:"Only logs the orphaned volumes instead of unmounting and removing them" split_words:"true"`
+	GCPodListEnabled bool          `default:"false" desc:"Collects the volumes of the pods the kubelet no longer runs, requires the in-cluster config" split_words:"true"`
+	KubeletPodsDir   string        `default:"/var/lib/kubelet/pods" desc:"Path to the kubelet pods directory" split_words:"true"`
+
+	KubeletRegistrationEnabled bool   `default:"false" desc:"Enables registration with the kubelet, replacing the node-driver-registrar sidecar" split_words:"true"`
+	KubeletRegistrationDir     string `default:"/registration" desc:"Path to the kubelet plugins_registry directory" split_words:"true"`
+	KubeletRegistrationPath    string `default:"" desc:"Path to the CSI socket on the host, used by the kubelet" split_words:"true"`
 }
 
 // IsValid - check if configuration is valid
@@ -40,5 +84,54 @@ func (c *Config) IsValid() error {
 	if c.SocketDir == "" {
 		return errors.New("socket dir is required")
 	}
+	if c.TargetDirMode&^os.ModePerm != 0 {
+		return errors.Errorf("target dir mode %#o is not a permission mode", uint32(c.TargetDirMode))
+	}
+	if c.TargetDirUID < -1 || c.TargetDirGID < -1 {
+		return errors.New("target dir UID and GID must be non-negative or -1")
+	}
+	if c.ControllerEnabled && !c.PersistentVolumesEnabled {
+		return errors.New("controller requires persistent volumes to be enabled")
+	}
+	if c.OperationTimeout < 0 {
+		return errors.New("operation timeout must not be negative")
+	}
+	if c.UnmountRetries < 0 || c.UnmountRetryInterval < 0 {
+		return errors.New("unmount retries and retry interval must not be negative")
+	}
+	if c.SocketWaitTimeout < 0 {
+		return errors.New("socket wait timeout must not be negative")
+	}
+	if c.GCInterval < 0 {
+		return errors.New("GC interval must not be negative")
+	}
+	if c.MaxVolumesPerNode < 0 {
+		return errors.New("max volumes per node must not be negative")
+	}
+	if c.AuditLogMaxSize <= 0 || c.AuditLogMaxBackups <= 0 {
+		return errors.New("audit log max size and max backups must be positive")
+	}
+	if c.KubeletRegistrationEnabled && c.KubeletRegistrationPath == "" {
+		return errors.New("kubelet registration path is required when kubelet registration is enabled")
+	}
 	return nil
 }
+
+// Topology - returns the topology segments of the node, merging the static segments with the ones read from env
+func (c *Config) Topology() (map[string]string, error) {
+	if len(c.TopologySegments) == 0 && len(c.TopologyLabelEnvs) == 0 {
+		return nil, nil
+	}
+	segments := make(map[string]string, len(c.TopologySegments)+len(c.TopologyLabelEnvs))
+	for key, value := range c.TopologySegments {
+		segments[key] = value
+	}
+	for key, env := range c.TopologyLabelEnvs {
+		value := os.Getenv(env)
+		if value == "" {
+			return nil, errors.Errorf("env %s for topology segment %s is not set", env, key)
+		}
+		segments[key] = value
+	}
+	return segments, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValid(t *testing.T) {
+	for _, tt := range []struct {
+		desc      string
+		env       map[string]string
+		expectErr string
+	}{
+		{
+			desc: "defaults",
+		},
+		{
+			desc:      "node name is required",
+			env:       map[string]string{"NSM_NODE_NAME": ""},
+			expectErr: "node name is required",
+		},
+		{
+			desc:      "controller without persistent volumes",
+			env:       map[string]string{"NSM_CONTROLLER_ENABLED": "true"},
+			expectErr: "controller requires persistent volumes to be enabled",
+		},
+		{
+			desc: "controller with persistent volumes",
+			env: map[string]string{
+				"NSM_CONTROLLER_ENABLED":         "true",
+				"NSM_PERSISTENT_VOLUMES_ENABLED": "true",
+			},
+		},
+		{
+			desc:      "negative operation timeout",
+			env:       map[string]string{"NSM_OPERATION_TIMEOUT": "-1s"},
+			expectErr: "operation timeout must not be negative",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Setenv("NSM_NODE_NAME", "node")
+			t.Setenv("NSM_SOCKET_DIR", "/var/lib/networkservicemesh")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			c := &Config{}
+			require.NoError(t, envconfig.Process("nsm", c))
+			err := c.IsValid()
+			if tt.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.expectErr)
+			}
+		})
+	}
+}
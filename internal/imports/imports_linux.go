@@ -2,26 +2,69 @@
 package imports
 
 import (
+	_ "bufio"
+	_ "bytes"
 	_ "context"
+	_ "crypto/rand"
+	_ "crypto/sha256"
+	_ "encoding/hex"
 	_ "encoding/json"
+	_ "flag"
 	_ "fmt"
 	_ "github.com/container-storage-interface/spec/lib/go/csi"
 	_ "github.com/kelseyhightower/envconfig"
+	_ "github.com/kubernetes-csi/csi-test/v5/pkg/sanity"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/log"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/log/logruslogger"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/pprofutils"
+	_ "github.com/onsi/ginkgo/v2"
+	_ "github.com/onsi/gomega"
 	_ "github.com/pkg/errors"
-	_ "github.com/spiffe/spiffe-csi/pkg/mount"
+	_ "github.com/sirupsen/logrus"
 	_ "github.com/stretchr/testify/assert"
 	_ "github.com/stretchr/testify/require"
+	_ "go.opentelemetry.io/otel"
+	_ "go.opentelemetry.io/otel/attribute"
+	_ "go.opentelemetry.io/otel/metric"
+	_ "go.opentelemetry.io/otel/sdk/metric"
+	_ "go.opentelemetry.io/otel/sdk/metric/metricdata"
+	_ "golang.org/x/sys/unix"
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails"
 	_ "google.golang.org/grpc"
 	_ "google.golang.org/grpc/codes"
 	_ "google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/metadata"
+	_ "google.golang.org/grpc/peer"
 	_ "google.golang.org/grpc/status"
+	_ "io"
 	_ "io/fs"
+	_ "k8s.io/api/core/v1"
+	_ "k8s.io/apimachinery/pkg/apis/meta/v1"
+	_ "k8s.io/apimachinery/pkg/fields"
+	_ "k8s.io/apimachinery/pkg/types"
+	_ "k8s.io/client-go/kubernetes"
+	_ "k8s.io/client-go/kubernetes/fake"
+	_ "k8s.io/client-go/kubernetes/scheme"
+	_ "k8s.io/client-go/kubernetes/typed/core/v1"
+	_ "k8s.io/client-go/rest"
+	_ "k8s.io/client-go/tools/record"
+	_ "k8s.io/kubelet/pkg/apis/pluginregistration/v1"
+	_ "math"
 	_ "net"
 	_ "os"
+	_ "os/exec"
+	_ "path"
 	_ "path/filepath"
+	_ "regexp"
+	_ "runtime"
+	_ "runtime/debug"
+	_ "slices"
+	_ "strconv"
 	_ "strings"
+	_ "sync"
+	_ "syscall"
 	_ "testing"
 	_ "time"
+	_ "unicode"
 )
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging configures the log level and output format of the driver
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// FormatText is the human readable log output format
+	FormatText = "text"
+	// FormatJSON is the log output format with one JSON object per line, the fields are named by the logkeys constants
+	FormatJSON = "json"
+)
+
+// New creates a logger writing to out with the level and the output format
+func New(out io.Writer, level, format string) (log.Logger, *logrus.Logger, error) {
+	l := logrus.New()
+	if err := configure(l, out, level, format); err != nil {
+		return nil, nil, err
+	}
+	return &logger{entry: logrus.NewEntry(l)}, l, nil
+}
+
+// Setup configures the standard logrus logger with the level and the output format, sets it as the global logger and
+// returns it. Until ctx is done, SIGUSR1 switches the level to TRACE and SIGUSR2 switches it back to the configured
+// level, where the signals are supported.
+func Setup(ctx context.Context, level, format string) (log.Logger, error) {
+	l := logrus.StandardLogger()
+	if err := configure(l, os.Stderr, level, format); err != nil {
+		return nil, err
+	}
+	logger := &logger{entry: logrus.NewEntry(l)}
+	log.SetGlobalLogger(logger)
+	setupLevelChangeOnSignal(log.WithLog(ctx, logger), l.GetLevel())
+	return logger, nil
+}
+
+func configure(l *logrus.Logger, out io.Writer, level, format string) error {
+	logLevel, err := logrus.ParseLevel(level)
+	if err != nil {
+		return errors.Wrapf(err, "invalid log level %q", level)
+	}
+
+	l.SetOutput(out)
+	l.SetLevel(logLevel)
+	switch strings.ToLower(format) {
+	case FormatText:
+		l.SetFormatter(&logrus.TextFormatter{
+			FullTimestamp:   true,
+			TimestampFormat: time.RFC3339Nano,
+		})
+	case FormatJSON:
+		l.SetFormatter(&logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339Nano,
+		})
+	default:
+		return errors.Errorf("invalid log format %q, must be %s or %s", format, FormatText, FormatJSON)
+	}
+	return nil
+}
+
+// logger adapts a logrus entry to the NSM logger, the fields are written as is, so in the JSON output every logkeys
+// constant is the name of its field
+type logger struct {
+	entry *logrus.Entry
+}
+
+func (l *logger) Info(v ...interface{})                  { l.entry.Info(v...) }
+func (l *logger) Infof(format string, v ...interface{})  { l.entry.Infof(format, v...) }
+func (l *logger) Warn(v ...interface{})                  { l.entry.Warn(v...) }
+func (l *logger) Warnf(format string, v ...interface{})  { l.entry.Warnf(format, v...) }
+func (l *logger) Error(v ...interface{})                 { l.entry.Error(v...) }
+func (l *logger) Errorf(format string, v ...interface{}) { l.entry.Errorf(format, v...) }
+func (l *logger) Fatal(v ...interface{})                 { l.entry.Fatal(v...) }
+func (l *logger) Fatalf(format string, v ...interface{}) { l.entry.Fatalf(format, v...) }
+func (l *logger) Debug(v ...interface{})                 { l.entry.Debug(v...) }
+func (l *logger) Debugf(format string, v ...interface{}) { l.entry.Debugf(format, v...) }
+func (l *logger) Trace(v ...interface{})                 { l.entry.Trace(v...) }
+func (l *logger) Tracef(format string, v ...interface{}) { l.entry.Tracef(format, v...) }
+
+func (l *logger) Object(k, v interface{}) {
+	msg, err := json.Marshal(v)
+	if err != nil {
+		l.entry.Infof("%v=%v", k, v)
+		return
+	}
+	l.entry.Infof("%v=%s", k, msg)
+}
+
+func (l *logger) WithField(key, value interface{}) log.Logger {
+	k, ok := key.(string)
+	if !ok {
+		k = fmt.Sprint(key)
+	}
+	return &logger{entry: l.entry.WithField(k, value)}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/logkeys"
+)
+
+func TestNew(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		level  string
+		format string
+		err    string
+	}{
+		{desc: "text", level: "INFO", format: "text"},
+		{desc: "json", level: "debug", format: "JSON"},
+		{desc: "invalid level", level: "loud", format: "text", err: `invalid log level "loud": not a valid logrus Level: "loud"`},
+		{desc: "invalid format", level: "INFO", format: "xml", err: `invalid log format "xml", must be text or json`},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, _, err := New(&bytes.Buffer{}, tt.level, tt.format)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestJSONFields(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger, _, err := New(out, "INFO", FormatJSON)
+	require.NoError(t, err)
+
+	logger.WithField(logkeys.VolumeID, "volumeID").
+		WithField(logkeys.TargetPath, "/target").
+		Info("Volume published")
+
+	fields := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &fields))
+	require.Equal(t, "volumeID", fields["volumeID"])
+	require.Equal(t, "/target", fields["targetPath"])
+	require.Equal(t, "Volume published", fields["msg"])
+	require.Equal(t, "info", fields["level"])
+	require.Contains(t, fields, "time")
+}
+
+func TestLevel(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger, l, err := New(out, "INFO", FormatText)
+	require.NoError(t, err)
+
+	logger.Debug("hidden")
+	require.Empty(t, out.String())
+
+	l.SetLevel(l.GetLevel() + 1)
+	logger.Debug("shown")
+	require.Contains(t, out.String(), "shown")
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package nstest
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// namespaceEnv is set to the name of the test re-executed in the namespaces
+const namespaceEnv = "NSM_NSTEST_NAMESPACE"
+
+// Run re-executes the running top-level test in new user and mount namespaces, where the test user is mapped to root.
+// Outside of the namespaces Run returns false once the re-executed test completes, and the test must return. In the
+// namespaces Run makes the mounts private and returns true, so the test goes on with real mounts. The test is skipped
+// if the namespaces can't be created or mounted in, e.g. unprivileged user namespaces are disabled.
+func Run(t *testing.T) bool {
+	t.Helper()
+
+	if os.Getenv(namespaceEnv) == t.Name() {
+		// Keep the mounts of the test from propagating back to the host
+		if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+			if errors.Is(err, unix.EPERM) {
+				t.Skipf("mounts are not permitted in the mount namespace: %v", err)
+			}
+			t.Fatalf("unable to make the mounts private: %v", err)
+		}
+		return true
+	}
+
+	// #nosec G204 -- the test binary re-executes itself
+	cmd := exec.Command(os.Args[0], "-test.run=^"+regexp.QuoteMeta(t.Name())+"$", "-test.count=1", "-test.v")
+	cmd.Env = append(os.Environ(), namespaceEnv+"="+t.Name())
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS,
+		UidMappings: []syscall.SysProcIDMap{
+			{ContainerID: 0, HostID: os.Getuid(), Size: 1},
+		},
+		GidMappings: []syscall.SysProcIDMap{
+			{ContainerID: 0, HostID: os.Getgid(), Size: 1},
+		},
+	}
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Start(); err != nil {
+		t.Skipf("user and mount namespaces are unavailable: %v", err)
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Fatalf("%s failed in the namespaces: %v\n%s", t.Name(), err, output.String())
+	}
+	if strings.Contains(output.String(), "--- SKIP: "+t.Name()) {
+		t.Skipf("%s skipped in the namespaces:\n%s", t.Name(), output.String())
+	}
+	t.Logf("%s passed in the namespaces:\n%s", t.Name(), output.String())
+	return false
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package version - contain build metadata of the csi driver binary
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Build metadata, can be set at build time with
+// -ldflags "-X github.com/networkservicemesh/cmd-csi-driver/internal/version.version=v1.0.0"
+var (
+	version   string
+	gitCommit string
+	buildDate string
+)
+
+// Info - build metadata of the binary
+type Info struct {
+	Version   string
+	GitCommit string
+	BuildDate string
+	GoVersion string
+}
+
+// Get - returns the build metadata, values not set with ldflags are taken from the embedded build info
+func Get() Info {
+	info := Info{
+		Version:   version,
+		GitCommit: gitCommit,
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+	}
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.Version == "" {
+		info.Version = buildInfo.Main.Version
+	}
+	for _, setting := range buildInfo.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && info.GitCommit == "":
+			info.GitCommit = setting.Value
+		case setting.Key == "vcs.time" && info.BuildDate == "":
+			info.BuildDate = setting.Value
+		}
+	}
+	return info
+}
+
+// Manifest - returns the build metadata as a CSI plugin manifest
+func (i Info) Manifest() map[string]string {
+	manifest := map[string]string{
+		"goVersion": i.GoVersion,
+	}
+	for key, value := range map[string]string{
+		"version":   i.Version,
+		"gitCommit": i.GitCommit,
+		"buildDate": i.BuildDate,
+	} {
+		if value != "" {
+			manifest[key] = value
+		}
+	}
+	return manifest
+}
+
+func (i Info) String() string {
+	return fmt.Sprintf("version: %s, git commit: %s, build date: %s, go version: %s", i.Version, i.GitCommit, i.BuildDate, i.GoVersion)
+}
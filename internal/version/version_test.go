@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package version
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	defer func(v, c, d string) { version, gitCommit, buildDate = v, c, d }(version, gitCommit, buildDate)
+	version, gitCommit, buildDate = "v1.14.0", "0123456789abcdef", "2026-10-19T00:00:00Z"
+
+	require.Equal(t, Info{
+		Version:   "v1.14.0",
+		GitCommit: "0123456789abcdef",
+		BuildDate: "2026-10-19T00:00:00Z",
+		GoVersion: runtime.Version(),
+	}, Get())
+}
+
+func TestGetWithoutLdflags(t *testing.T) {
+	defer func(v, c, d string) { version, gitCommit, buildDate = v, c, d }(version, gitCommit, buildDate)
+	version, gitCommit, buildDate = "", "", ""
+
+	// The test binary has no VCS stamp, the version of its main module is "(devel)" or empty
+	info := Get()
+	require.Equal(t, runtime.Version(), info.GoVersion)
+	require.Contains(t, []string{"", "(devel)"}, info.Version)
+}
+
+func TestManifest(t *testing.T) {
+	require.Equal(t, map[string]string{
+		"version":   "v1.14.0",
+		"gitCommit": "0123456789abcdef",
+		"buildDate": "2026-10-19T00:00:00Z",
+		"goVersion": "go1.24.10",
+	}, Info{
+		Version:   "v1.14.0",
+		GitCommit: "0123456789abcdef",
+		BuildDate: "2026-10-19T00:00:00Z",
+		GoVersion: "go1.24.10",
+	}.Manifest())
+
+	// The unset values are left out
+	require.Equal(t, map[string]string{"goVersion": "go1.24.10"}, Info{GoVersion: "go1.24.10"}.Manifest())
+}
+
+func TestString(t *testing.T) {
+	require.Equal(t, "version: v1.14.0, git commit: abc, build date: today, go version: go1.24.10",
+		Info{Version: "v1.14.0", GitCommit: "abc", BuildDate: "today", GoVersion: "go1.24.10"}.String())
+}
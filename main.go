@@ -1,4 +1,4 @@
-// Copyright (c) 2023-2024 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -18,21 +18,46 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
+	"io"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	"github.com/networkservicemesh/cmd-csi-driver/internal/config"
+	"github.com/networkservicemesh/cmd-csi-driver/internal/logging"
+	"github.com/networkservicemesh/cmd-csi-driver/internal/version"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/audit"
 	"github.com/networkservicemesh/cmd-csi-driver/pkg/driver"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/events"
 	"github.com/networkservicemesh/cmd-csi-driver/pkg/logkeys"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/registration"
 	"github.com/networkservicemesh/cmd-csi-driver/pkg/server"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/unixsocket"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
 	"github.com/networkservicemesh/sdk/pkg/tools/pprofutils"
 )
 
+const undefinedVersion = "undefined"
+
 func main() {
+	showVersion := flag.Bool("version", false, "Print version information and exit")
+	flag.Parse()
+
+	buildInfo := version.Get()
+	if *showVersion {
+		fmt.Println(buildInfo)
+		return
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	log.EnableTracing(true)
 	logger := log.FromContext(ctx)
 
 	c := &config.Config{}
@@ -42,36 +67,255 @@ func main() {
 	if err := envconfig.Process("nsm", c); err != nil {
 		logger.Fatalf("error processing rootConf from env: %+v", err)
 	}
+	if err := c.IsValid(); err != nil {
+		logger.Fatalf("invalid configuration: %v", err)
+	}
+	logger, err := logging.Setup(ctx, c.LogLevel, c.LogFormat)
+	if err != nil {
+		log.FromContext(ctx).Fatalf("invalid logging configuration: %v", err)
+	}
+	ctx = log.WithLog(ctx, logger)
+	if c.Version == undefinedVersion && buildInfo.Version != "" {
+		c.Version = buildInfo.Version
+	}
 
 	// Configure pprof
 	if c.PprofEnabled {
 		go pprofutils.ListenAndServe(ctx, c.PprofListenOn)
 	}
 
+	// Configure Open Telemetry
+	if opentelemetry.IsEnabled() {
+		o := initOpenTelemetry(ctx, c)
+		defer func() {
+			if closeErr := o.Close(); closeErr != nil {
+				logger.Error(closeErr.Error())
+			}
+		}()
+	}
+
 	logger.WithField(logkeys.Version, c.Version).
+		WithField(logkeys.GitCommit, buildInfo.GitCommit).
 		WithField(logkeys.NodeID, c.NodeName).
 		WithField(logkeys.NSMSocketDir, c.SocketDir).
 		WithField(logkeys.CSISocketPath, c.CSISocketPath).Info("Starting")
 
-	d, err := driver.New(&driver.Config{
-		Log:          logger,
-		NodeID:       c.NodeName,
-		PluginName:   c.PluginName,
-		Version:      c.Version,
-		NSMSocketDir: c.SocketDir,
-	})
+	registrar, err := newRegistrar(c, logger)
+	if err != nil {
+		logger.Fatalf("Failed to create kubelet registrar: %v", err)
+	}
+	auditLog, err := openAuditLog(c)
+	if err != nil {
+		logger.Fatalf("Failed to open audit log: %v", err)
+	}
+	if auditLog != nil {
+		defer func() { _ = auditLog.Close() }()
+	}
+	d, err := newDriver(ctx, c, logger, buildInfo, registrar, auditLog)
 	if err != nil {
 		logger.Fatalf("Failed to create driver: %v", err)
 	}
 
-	serverConfig := server.Config{
-		Log:           logger,
-		CSISocketPath: c.CSISocketPath,
-		Driver:        d,
+	if c.GCInterval > 0 {
+		go d.RunGarbageCollector(ctx, c.GCInterval)
+	}
+
+	if registrar != nil {
+		go func() {
+			if err := registrar.Run(ctx); err != nil {
+				logger.Errorf("Kubelet registration failed: %v", err)
+			}
+		}()
 	}
 
-	if err := server.Run(serverConfig); err != nil {
+	if err := serve(ctx, c, logger, d); err != nil {
 		logger.Fatalf("Failed to serve:  %v", err)
 	}
 	logger.Info("Done")
 }
+
+// initOpenTelemetry sets the global tracer and meter providers exporting to the OpenTelemetry collector
+func initOpenTelemetry(ctx context.Context, c *config.Config) io.Closer {
+	spanExporter := opentelemetry.InitSpanExporter(ctx, c.OpenTelemetryEndpoint)
+	metricExporter := opentelemetry.InitOPTLMetricExporter(ctx, c.OpenTelemetryEndpoint, c.MetricsExportInterval)
+	return opentelemetry.Init(ctx, spanExporter, metricExporter, c.PluginName)
+}
+
+// newDriver creates the driver with its kubernetes clients, the registrar and the audit log are created by main as they
+// outlive it
+func newDriver(ctx context.Context, c *config.Config, logger log.Logger, buildInfo version.Info, registrar *registration.Registrar, auditLog *audit.Log) (*driver.Driver, error) {
+	topology, err := c.Topology()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid topology configuration")
+	}
+	recorder, err := newEventRecorder(ctx, c)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create event recorder")
+	}
+	podUIDs, err := newPodUIDs(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create kubernetes client")
+	}
+	var probes []driver.ProbeFunc
+	if registrar != nil {
+		probes = append(probes, registrar.Check)
+	}
+
+	return driver.New(&driver.Config{
+		Log:           logger,
+		NodeID:        c.NodeName,
+		PluginName:    c.PluginName,
+		Version:       c.Version,
+		NSMSocketDir:  c.SocketDir,
+		TargetDirMode: c.TargetDirMode,
+		TargetDirUID:  optionalID(c.TargetDirUID),
+		TargetDirGID:  optionalID(c.TargetDirGID),
+
+		PersistentVolumes: c.PersistentVolumesEnabled,
+		Controller:        c.ControllerEnabled,
+		Topology:          topology,
+		MaxVolumesPerNode: c.MaxVolumesPerNode,
+		Manifest:          buildInfo.Manifest(),
+		Probes:            probes,
+		AuditLog:          auditLog,
+		Events:            recorder,
+		OperationTimeout:  c.OperationTimeout,
+
+		UnmountRetries:       c.UnmountRetries,
+		UnmountRetryInterval: c.UnmountRetryInterval,
+		LazyUnmount:          c.LazyUnmountEnabled,
+		NSMSocketName:        c.SocketName,
+		NSMSocketWaitTimeout: c.SocketWaitTimeout,
+
+		KubeletPodsDir: c.KubeletPodsDir,
+		PodUIDs:        podUIDs,
+		GCDryRun:       c.GCDryRun,
+	})
+}
+
+// newRegistrar creates the kubelet registrar if the registration is enabled
+func newRegistrar(c *config.Config, logger log.Logger) (*registration.Registrar, error) {
+	if !c.KubeletRegistrationEnabled {
+		return nil, nil
+	}
+	return registration.New(&registration.Config{
+		Log:             logger,
+		PluginName:      c.PluginName,
+		RegistrationDir: c.KubeletRegistrationDir,
+		Endpoint:        c.KubeletRegistrationPath,
+	})
+}
+
+// openAuditLog opens the audit log if its path is set
+func openAuditLog(c *config.Config) (*audit.Log, error) {
+	if c.AuditLogPath == "" {
+		return nil, nil
+	}
+	return audit.Open(&audit.Config{
+		Path:       c.AuditLogPath,
+		MaxSize:    c.AuditLogMaxSize,
+		MaxBackups: c.AuditLogMaxBackups,
+	})
+}
+
+// newPodUIDs creates the pod list of the garbage collector if the collection and the pod list are enabled
+func newPodUIDs(c *config.Config) (driver.PodUIDsFunc, error) {
+	if c.GCInterval <= 0 || !c.GCPodListEnabled {
+		return nil, nil
+	}
+	client, err := newKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	return nodePodUIDs(client, c.NodeName), nil
+}
+
+// serve serves the driver on the socket activation listener if the service manager passed one, otherwise on the CSI
+// socket path until ctx is done
+func serve(ctx context.Context, c *config.Config, logger log.Logger, d *driver.Driver) error {
+	serverConfig := server.Config{
+		Log:                 logger,
+		CSISocketPath:       c.CSISocketPath,
+		Driver:              d,
+		SocketCheckInterval: c.CSISocketCheckInterval,
+		DefaultTimeout:      c.RPCTimeout,
+		MethodTimeouts:      c.RPCMethodTimeouts,
+	}
+	if c.ControllerEnabled {
+		serverConfig.Controller = d
+	}
+
+	listeners, err := unixsocket.ActivationListeners()
+	if err != nil {
+		return errors.Wrap(err, "unable to get socket activation listeners")
+	}
+	if len(listeners) == 0 {
+		return server.Run(serverConfig)
+	}
+
+	// The CSI socket is managed by the service manager, so it is served as is
+	for _, l := range listeners[1:] {
+		_ = l.Close()
+	}
+	logger.WithField(logkeys.CSISocketPath, listeners[0].Addr().String()).Info("Serving on the socket activation listener")
+	return server.Serve(ctx, listeners[0],
+		server.WithLog(logger),
+		server.WithDriver(d),
+		server.WithController(serverConfig.Controller),
+		server.WithDefaultTimeout(c.RPCTimeout),
+		server.WithMethodTimeouts(c.RPCMethodTimeouts),
+	)
+}
+
+// newKubeClient creates a kubernetes client with the in-cluster config of the driver service account
+func newKubeClient() (kubernetes.Interface, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// newEventRecorder creates the event recorder with the in-cluster config of the driver service account if the events
+// are enabled, it is shut down when ctx is done
+func newEventRecorder(ctx context.Context, c *config.Config) (*events.Recorder, error) {
+	if !c.EventsEnabled {
+		return nil, nil
+	}
+	client, err := newKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	recorder, err := events.New(&events.Config{
+		Client:    client,
+		Component: c.PluginName,
+		NodeName:  c.NodeName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	context.AfterFunc(ctx, recorder.Shutdown)
+	return recorder, nil
+}
+
+// optionalID converts -1 (keep the current ID) to nil
+func optionalID(id int) *int {
+	if id < 0 {
+		return nil
+	}
+	return &id
+}
+
+// nodePodUIDs lists the local UIDs of the pods of the node in the API server. The static pods are known there by the
+// UIDs of their mirror pods, LocalPodUIDs adds their local ones.
+func nodePodUIDs(client kubernetes.Interface, nodeName string) driver.PodUIDsFunc {
+	return func(ctx context.Context) (map[string]bool, error) {
+		pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return driver.LocalPodUIDs(pods.Items), nil
+	}
+}
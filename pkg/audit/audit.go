@@ -0,0 +1,190 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit writes an append-only log of the pods given access to the NSM API, one JSON line per event
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Actions of the audit events
+const (
+	ActionPublish   = "publish"
+	ActionUnpublish = "unpublish"
+)
+
+// Results of the audit events
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+const (
+	defaultMaxSize    = 10 << 20
+	defaultMaxBackups = 3
+	fileMode          = 0o600
+)
+
+// Event is a single audit log entry
+type Event struct {
+	Time           time.Time `json:"time"`
+	Action         string    `json:"action"`
+	VolumeID       string    `json:"volumeID"`
+	TargetPath     string    `json:"targetPath"`
+	PodName        string    `json:"podName,omitempty"`
+	PodNamespace   string    `json:"podNamespace,omitempty"`
+	PodUID         string    `json:"podUID,omitempty"`
+	ServiceAccount string    `json:"serviceAccount,omitempty"`
+	Result         string    `json:"result"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// Config is used to open the audit log
+type Config struct {
+	// Path is the audit log file, the rotated files are named <Path>.1, <Path>.2, ...
+	Path string
+	// MaxSize is the size in bytes the file is rotated at, 10MiB if unset
+	MaxSize int64
+	// MaxBackups is the number of rotated files kept, 3 if unset
+	MaxBackups int
+}
+
+// Log is an append-only audit log rotated by size. It is independent of the driver logger, so the events are kept
+// whatever the log level and output are.
+type Log struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// Open opens the audit log with the given config, appending to the existing file
+func Open(config *Config) (*Log, error) {
+	if config.Path == "" {
+		return nil, errors.New("audit log path is required")
+	}
+	l := &Log{
+		path:       config.Path,
+		maxSize:    config.MaxSize,
+		maxBackups: config.MaxBackups,
+	}
+	if l.maxSize <= 0 {
+		l.maxSize = defaultMaxSize
+	}
+	if l.maxBackups <= 0 {
+		l.maxBackups = defaultMaxBackups
+	}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Record appends the event to the audit log, the time is set if it is zero
+func (l *Log) Record(event *Event) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	line, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "unable to encode audit event")
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return errors.New("audit log is closed")
+	}
+	var rotateErr error
+	if l.size > 0 && l.size+int64(len(line)) > l.maxSize {
+		if rotateErr = l.rotate(); l.file == nil {
+			return rotateErr
+		}
+	}
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		return errors.Wrapf(err, "unable to write audit log %s", l.path)
+	}
+	return rotateErr
+}
+
+// Close closes the audit log
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+func (l *Log) open() error {
+	file, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, fileMode)
+	if err != nil {
+		return errors.Wrapf(err, "unable to open audit log %s", l.path)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return errors.Wrapf(err, "unable to stat audit log %s", l.path)
+	}
+	l.file = file
+	l.size = info.Size()
+	return nil
+}
+
+// rotate shifts the backups, dropping the oldest one, and starts a new file
+func (l *Log) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return errors.Wrapf(err, "unable to close audit log %s", l.path)
+	}
+	l.file = nil
+
+	var err error
+	for i := l.maxBackups - 1; i > 0 && err == nil; i-- {
+		if err = os.Rename(backupPath(l.path, i), backupPath(l.path, i+1)); os.IsNotExist(err) {
+			err = nil
+		}
+	}
+	if err == nil {
+		err = os.Rename(l.path, backupPath(l.path, 1))
+	}
+	// The events are still appended to the current file if it can't be rotated
+	if openErr := l.open(); openErr != nil {
+		return openErr
+	}
+	return errors.Wrapf(err, "unable to rotate audit log %s", l.path)
+}
+
+func backupPath(path string, i int) string {
+	return fmt.Sprintf("%s.%d", path, i)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readEvents(t *testing.T, path string) []Event {
+	file, err := os.Open(filepath.Clean(path))
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	var events []Event
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func TestOpen(t *testing.T) {
+	_, err := Open(&Config{})
+	require.EqualError(t, err, "audit log path is required")
+
+	_, err = Open(&Config{Path: filepath.Join(t.TempDir(), "missing", "audit.log")})
+	require.Error(t, err)
+}
+
+func TestRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	l, err := Open(&Config{Path: path})
+	require.NoError(t, err)
+
+	require.NoError(t, l.Record(&Event{
+		Action:       ActionPublish,
+		VolumeID:     "volumeID",
+		TargetPath:   "/target",
+		PodName:      "pod",
+		PodNamespace: "default",
+		Result:       ResultSuccess,
+	}))
+	require.NoError(t, l.Close())
+
+	// The existing events are kept when the log is opened again
+	l, err = Open(&Config{Path: path})
+	require.NoError(t, err)
+	require.NoError(t, l.Record(&Event{Action: ActionUnpublish, VolumeID: "volumeID", Result: ResultFailure, Error: "boom"}))
+	require.NoError(t, l.Close())
+	require.EqualError(t, l.Record(&Event{}), "audit log is closed")
+
+	events := readEvents(t, path)
+	require.Len(t, events, 2)
+	require.Equal(t, ActionPublish, events[0].Action)
+	require.Equal(t, "pod", events[0].PodName)
+	require.Equal(t, "default", events[0].PodNamespace)
+	require.False(t, events[0].Time.IsZero())
+	require.Equal(t, ActionUnpublish, events[1].Action)
+	require.Equal(t, ResultFailure, events[1].Result)
+	require.Equal(t, "boom", events[1].Error)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(fileMode), info.Mode().Perm())
+}
+
+func TestRotate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	l, err := Open(&Config{Path: path, MaxSize: 200, MaxBackups: 2})
+	require.NoError(t, err)
+	defer func() { _ = l.Close() }()
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, l.Record(&Event{Action: ActionPublish, VolumeID: "volumeID", Result: ResultSuccess}))
+	}
+
+	for _, p := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(p)
+		require.NoError(t, err)
+		require.LessOrEqual(t, info.Size(), int64(200))
+		require.NotEmpty(t, readEvents(t, p))
+	}
+	_, err = os.Stat(path + ".3")
+	require.True(t, os.IsNotExist(err))
+}
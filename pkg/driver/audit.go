@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/audit"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// audit records the publish or unpublish attempt in the audit log. A failure to record it is logged, it doesn't
+// fail the RPC.
+func (d *Driver) audit(logger log.Logger, action, volumeID, targetPath string, volumeContext map[string]string, err error) {
+	if d.auditLog == nil {
+		return
+	}
+	event := &audit.Event{
+		Action:         action,
+		VolumeID:       volumeID,
+		TargetPath:     targetPath,
+		PodName:        volumeContext[podNameKey],
+		PodNamespace:   volumeContext[podNamespaceKey],
+		PodUID:         volumeContext[podUIDKey],
+		ServiceAccount: volumeContext[podServiceAccountKey],
+		Result:         audit.ResultSuccess,
+	}
+	if event.PodUID == "" {
+		event.PodUID = podUIDFromTargetPath(targetPath)
+	}
+	if err != nil {
+		event.Result = audit.ResultFailure
+		event.Error = err.Error()
+	}
+	if err := d.auditLog.Record(event); err != nil {
+		logger.Errorf("Failed to record audit event: %v", err)
+	}
+}
+
+// podUIDFromTargetPath returns the pod UID of a kubelet target path, /var/lib/kubelet/pods/<uid>/volumes/..., as
+// the unpublish requests carry no pod info
+func podUIDFromTargetPath(targetPath string) string {
+	parts := strings.Split(filepath.ToSlash(filepath.Clean(targetPath)), "/")
+	for i := 0; i+2 < len(parts); i++ {
+		if parts[i] == "pods" && parts[i+2] == "volumes" {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/audit"
+)
+
+func readAuditEvents(t *testing.T, path string) []audit.Event {
+	file, err := os.Open(filepath.Clean(path))
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	var events []audit.Event
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event audit.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func TestAuditLog(t *testing.T) {
+	auditPath := filepath.Join(t.TempDir(), "audit.log")
+	auditLog, err := audit.Open(&audit.Config{Path: auditPath})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = auditLog.Close() })
+
+	client, _ := startDriver(t, func(config *Config) {
+		config.AuditLog = auditLog
+	})
+
+	podDir := filepath.Join(t.TempDir(), "pods", "pod-uid", "volumes", "kubernetes.io~csi", "volumeID")
+	require.NoError(t, os.MkdirAll(podDir, 0o750))
+	targetPath := filepath.Join(podDir, "mount")
+
+	req := &csi.NodePublishVolumeRequest{
+		VolumeId:   "volumeID",
+		TargetPath: targetPath,
+		VolumeCapability: &csi.VolumeCapability{
+			AccessType: &csi.VolumeCapability_Mount{},
+			AccessMode: &csi.VolumeCapability_AccessMode{},
+		},
+		VolumeContext: map[string]string{
+			"csi.storage.k8s.io/ephemeral":           "true",
+			"csi.storage.k8s.io/pod.name":            "pod",
+			"csi.storage.k8s.io/pod.namespace":       "default",
+			"csi.storage.k8s.io/pod.uid":             "pod-uid",
+			"csi.storage.k8s.io/serviceAccount.name": "nsc",
+		},
+	}
+	_, err = client.NodePublishVolume(context.Background(), req)
+	require.Error(t, err)
+
+	req.Readonly = true
+	_, err = client.NodePublishVolume(context.Background(), req)
+	require.NoError(t, err)
+
+	_, err = client.NodeUnpublishVolume(context.Background(), &csi.NodeUnpublishVolumeRequest{
+		VolumeId:   "volumeID",
+		TargetPath: targetPath,
+	})
+	require.NoError(t, err)
+
+	events := readAuditEvents(t, auditPath)
+	require.Len(t, events, 3)
+	for _, event := range events {
+		require.False(t, event.Time.IsZero())
+		require.Equal(t, "volumeID", event.VolumeID)
+		require.Equal(t, targetPath, event.TargetPath)
+		require.Equal(t, "pod-uid", event.PodUID)
+	}
+
+	require.Equal(t, audit.ActionPublish, events[0].Action)
+	require.Equal(t, audit.ResultFailure, events[0].Result)
+	require.Contains(t, events[0].Error, "readOnly must be set")
+
+	require.Equal(t, audit.ActionPublish, events[1].Action)
+	require.Equal(t, audit.ResultSuccess, events[1].Result)
+	require.Equal(t, "pod", events[1].PodName)
+	require.Equal(t, "default", events[1].PodNamespace)
+	require.Equal(t, "nsc", events[1].ServiceAccount)
+	require.Empty(t, events[1].Error)
+
+	// The unpublish requests carry no pod info but the pod UID from the target path
+	require.Equal(t, audit.ActionUnpublish, events[2].Action)
+	require.Equal(t, audit.ResultSuccess, events[2].Result)
+	require.Empty(t, events[2].PodName)
+}
+
+func TestPodUIDFromTargetPath(t *testing.T) {
+	for targetPath, uid := range map[string]string{
+		"/var/lib/kubelet/pods/8f7e6d5c/volumes/kubernetes.io~csi/nsm/mount": "8f7e6d5c",
+		"/var/lib/kubelet/pods/8f7e6d5c/volumes/":                            "8f7e6d5c",
+		"/var/lib/kubelet/plugins/kubernetes.io/csi/nsm/globalmount":         "",
+		"/tmp/target-path": "",
+	} {
+		require.Equal(t, uid, podUIDFromTargetPath(targetPath), targetPath)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/logkeys"
+)
+
+const volumeIDPrefix = "nsm-"
+
+/////////////////////////////////////////////////////////////////////////////
+// Controller Server implementation
+/////////////////////////////////////////////////////////////////////////////
+
+// CreateVolume provisions a volume for a generic ephemeral volume claim. There is nothing to allocate, all the
+// work is done by NodeStageVolume and NodePublishVolume, so only the request is validated.
+func (d *Driver) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequest) (_ *csi.CreateVolumeResponse, err error) {
+	logger := d.requestLogger(ctx, req)
+
+	defer func() {
+		if err != nil {
+			logger.Error(err, "Failed to create volume")
+		}
+	}()
+
+	// Validate request
+	switch {
+	case !d.controller:
+		return nil, status.Error(codes.Unimplemented, "controller service is not enabled")
+	case req.Name == "":
+		return nil, status.Error(codes.InvalidArgument, "request missing required volume name")
+	case len(req.VolumeCapabilities) == 0:
+		return nil, status.Error(codes.InvalidArgument, "request missing required volume capabilities")
+	case req.VolumeContentSource != nil:
+		return nil, status.Error(codes.InvalidArgument, "volume content source is not supported")
+	}
+	for _, volumeCapability := range req.VolumeCapabilities {
+		if err := validateVolumeCapability(volumeCapability); err != nil {
+			return nil, err
+		}
+	}
+	capacity, err := validateCapacityRange(req.CapacityRange)
+	if err != nil {
+		return nil, err
+	}
+
+	volume := &csi.Volume{
+		VolumeId:      volumeIDFromName(req.Name),
+		CapacityBytes: capacity,
+	}
+	if topology := d.volumeTopology(req.AccessibilityRequirements); topology != nil {
+		volume.AccessibleTopology = []*csi.Topology{topology}
+	}
+
+	logger.WithField(logkeys.VolumeID, volume.VolumeId).Info("Volume created")
+
+	return &csi.CreateVolumeResponse{Volume: volume}, nil
+}
+
+// DeleteVolume is a reverse operation of CreateVolume
+func (d *Driver) DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequest) (*csi.DeleteVolumeResponse, error) {
+	switch {
+	case !d.controller:
+		return nil, status.Error(codes.Unimplemented, "controller service is not enabled")
+	case req.VolumeId == "":
+		return nil, status.Error(codes.InvalidArgument, "request missing required volume id")
+	}
+
+	d.requestLogger(ctx, req).Info("Volume deleted")
+
+	return &csi.DeleteVolumeResponse{}, nil
+}
+
+// ValidateVolumeCapabilities checks whether the volume capabilities are supported
+func (d *Driver) ValidateVolumeCapabilities(_ context.Context, req *csi.ValidateVolumeCapabilitiesRequest) (*csi.ValidateVolumeCapabilitiesResponse, error) {
+	switch {
+	case !d.controller:
+		return nil, status.Error(codes.Unimplemented, "controller service is not enabled")
+	case req.VolumeId == "":
+		return nil, status.Error(codes.InvalidArgument, "request missing required volume id")
+	case len(req.VolumeCapabilities) == 0:
+		return nil, status.Error(codes.InvalidArgument, "request missing required volume capabilities")
+	}
+	for _, volumeCapability := range req.VolumeCapabilities {
+		if err := validateVolumeCapability(volumeCapability); err != nil {
+			return &csi.ValidateVolumeCapabilitiesResponse{
+				Message: status.Convert(err).Message(),
+			}, nil
+		}
+	}
+
+	return &csi.ValidateVolumeCapabilitiesResponse{
+		Confirmed: &csi.ValidateVolumeCapabilitiesResponse_Confirmed{
+			VolumeContext:      req.VolumeContext,
+			VolumeCapabilities: req.VolumeCapabilities,
+			Parameters:         req.Parameters,
+		},
+	}, nil
+}
+
+// ControllerGetCapabilities allows to check the supported capabilities of controller service provided by the Plugin
+func (d *Driver) ControllerGetCapabilities(_ context.Context, _ *csi.ControllerGetCapabilitiesRequest) (*csi.ControllerGetCapabilitiesResponse, error) {
+	if !d.controller {
+		return nil, status.Error(codes.Unimplemented, "controller service is not enabled")
+	}
+	return &csi.ControllerGetCapabilitiesResponse{
+		Capabilities: []*csi.ControllerServiceCapability{
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{
+						Type: csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// volumeTopology pins the volume to the topology it is requested for. The volume is usable on any node running the
+// driver, so the most preferred topology, normally the one of the node selected by the scheduler, is taken.
+func (d *Driver) volumeTopology(requirements *csi.TopologyRequirement) *csi.Topology {
+	if len(d.topology) == 0 {
+		return nil
+	}
+	if preferred := requirements.GetPreferred(); len(preferred) > 0 {
+		return preferred[0]
+	}
+	if requisite := requirements.GetRequisite(); len(requisite) > 0 {
+		return requisite[0]
+	}
+	return nil
+}
+
+// validateCapacityRange returns the capacity reported for the volume. The volume holds only the NSM socket, so any
+// consistent range is accepted and the required size is reported back.
+func validateCapacityRange(capacityRange *csi.CapacityRange) (int64, error) {
+	switch {
+	case capacityRange == nil:
+		return 0, nil
+	case capacityRange.RequiredBytes < 0 || capacityRange.LimitBytes < 0:
+		return 0, status.Error(codes.OutOfRange, "capacity range must not be negative")
+	case capacityRange.LimitBytes != 0 && capacityRange.LimitBytes < capacityRange.RequiredBytes:
+		return 0, status.Error(codes.OutOfRange, "capacity range limit is less than the required capacity")
+	}
+	return capacityRange.RequiredBytes, nil
+}
+
+// volumeIDFromName makes the same volume ID for every CreateVolume retry of the same volume name
+func volumeIDFromName(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return volumeIDPrefix + hex.EncodeToString(sum[:16])
+}
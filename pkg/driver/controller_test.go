@@ -0,0 +1,269 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func startController(t *testing.T) client {
+	c, _ := startDriver(t, func(config *Config) {
+		config.Controller = true
+		config.PersistentVolumes = true
+	})
+	return c
+}
+
+func TestControllerBoilerplateRPCs(t *testing.T) {
+	client := startController(t)
+
+	t.Run("GetPluginCapabilities", func(t *testing.T) {
+		resp, err := client.GetPluginCapabilities(context.Background(), &csi.GetPluginCapabilitiesRequest{})
+		require.NoError(t, err)
+		requireProtoEqual(t, &csi.GetPluginCapabilitiesResponse{
+			Capabilities: []*csi.PluginCapability{
+				{
+					Type: &csi.PluginCapability_Service_{
+						Service: &csi.PluginCapability_Service{
+							Type: csi.PluginCapability_Service_CONTROLLER_SERVICE,
+						},
+					},
+				},
+			},
+		}, resp, "unexpected response")
+	})
+
+	t.Run("ControllerGetCapabilities", func(t *testing.T) {
+		resp, err := client.ControllerGetCapabilities(context.Background(), &csi.ControllerGetCapabilitiesRequest{})
+		require.NoError(t, err)
+		requireProtoEqual(t, &csi.ControllerGetCapabilitiesResponse{
+			Capabilities: []*csi.ControllerServiceCapability{
+				{
+					Type: &csi.ControllerServiceCapability_Rpc{
+						Rpc: &csi.ControllerServiceCapability_RPC{
+							Type: csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
+						},
+					},
+				},
+			},
+		}, resp, "unexpected response")
+	})
+}
+
+func TestControllerDisabled(t *testing.T) {
+	client, _ := startDriver(t)
+
+	_, err := client.CreateVolume(context.Background(), &csi.CreateVolumeRequest{Name: "name"})
+	requireGRPCStatusPrefix(t, err, codes.Unimplemented, "controller service is not enabled")
+
+	_, err = client.DeleteVolume(context.Background(), &csi.DeleteVolumeRequest{VolumeId: "volumeID"})
+	requireGRPCStatusPrefix(t, err, codes.Unimplemented, "controller service is not enabled")
+}
+
+func TestCreateVolume(t *testing.T) {
+	client := startController(t)
+
+	for _, tt := range []struct {
+		desc            string
+		mutateReq       func(req *csi.CreateVolumeRequest)
+		expectCode      codes.Code
+		expectMsgPrefix string
+		expectCapacity  int64
+	}{
+		{
+			desc: "missing name",
+			mutateReq: func(req *csi.CreateVolumeRequest) {
+				req.Name = ""
+			},
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "request missing required volume name",
+		},
+		{
+			desc: "missing volume capabilities",
+			mutateReq: func(req *csi.CreateVolumeRequest) {
+				req.VolumeCapabilities = nil
+			},
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "request missing required volume capabilities",
+		},
+		{
+			desc: "block volume capability",
+			mutateReq: func(req *csi.CreateVolumeRequest) {
+				req.VolumeCapabilities[0].AccessType = &csi.VolumeCapability_Block{}
+			},
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "request volume capability access type must be a simple mount",
+		},
+		{
+			desc: "volume content source",
+			mutateReq: func(req *csi.CreateVolumeRequest) {
+				req.VolumeContentSource = &csi.VolumeContentSource{}
+			},
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "volume content source is not supported",
+		},
+		{
+			desc: "negative capacity",
+			mutateReq: func(req *csi.CreateVolumeRequest) {
+				req.CapacityRange = &csi.CapacityRange{RequiredBytes: -1}
+			},
+			expectCode:      codes.OutOfRange,
+			expectMsgPrefix: "capacity range must not be negative",
+		},
+		{
+			desc: "limit less than required",
+			mutateReq: func(req *csi.CreateVolumeRequest) {
+				req.CapacityRange = &csi.CapacityRange{RequiredBytes: 2, LimitBytes: 1}
+			},
+			expectCode:      codes.OutOfRange,
+			expectMsgPrefix: "capacity range limit is less than the required capacity",
+		},
+		{
+			desc: "success with capacity",
+			mutateReq: func(req *csi.CreateVolumeRequest) {
+				req.CapacityRange = &csi.CapacityRange{RequiredBytes: 1024, LimitBytes: 2048}
+			},
+			expectCode:     codes.OK,
+			expectCapacity: 1024,
+		},
+		{
+			desc:       "success",
+			expectCode: codes.OK,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			req := &csi.CreateVolumeRequest{
+				Name: "pvc-name",
+				VolumeCapabilities: []*csi.VolumeCapability{
+					{
+						AccessType: &csi.VolumeCapability_Mount{},
+						AccessMode: &csi.VolumeCapability_AccessMode{
+							Mode: csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY,
+						},
+					},
+				},
+			}
+			if tt.mutateReq != nil {
+				tt.mutateReq(req)
+			}
+
+			resp, err := client.CreateVolume(context.Background(), req)
+			requireGRPCStatusPrefix(t, err, tt.expectCode, tt.expectMsgPrefix)
+			if err != nil {
+				assert.Nil(t, resp)
+				return
+			}
+			assert.Equal(t, volumeIDFromName("pvc-name"), resp.GetVolume().GetVolumeId())
+			assert.Equal(t, tt.expectCapacity, resp.GetVolume().GetCapacityBytes())
+		})
+	}
+}
+
+func TestCreateVolumeIsDeterministic(t *testing.T) {
+	client := startController(t)
+
+	req := &csi.CreateVolumeRequest{
+		Name: "pvc-name",
+		VolumeCapabilities: []*csi.VolumeCapability{
+			{
+				AccessType: &csi.VolumeCapability_Mount{},
+				AccessMode: &csi.VolumeCapability_AccessMode{},
+			},
+		},
+	}
+	first, err := client.CreateVolume(context.Background(), req)
+	require.NoError(t, err)
+	second, err := client.CreateVolume(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, first.GetVolume().GetVolumeId(), second.GetVolume().GetVolumeId())
+
+	req.Name = "other-pvc-name"
+	other, err := client.CreateVolume(context.Background(), req)
+	require.NoError(t, err)
+	require.NotEqual(t, first.GetVolume().GetVolumeId(), other.GetVolume().GetVolumeId())
+}
+
+func TestDeleteVolume(t *testing.T) {
+	client := startController(t)
+
+	_, err := client.DeleteVolume(context.Background(), &csi.DeleteVolumeRequest{})
+	requireGRPCStatusPrefix(t, err, codes.InvalidArgument, "request missing required volume id")
+
+	resp, err := client.DeleteVolume(context.Background(), &csi.DeleteVolumeRequest{VolumeId: volumeIDFromName("pvc-name")})
+	require.NoError(t, err)
+	assert.Equal(t, &csi.DeleteVolumeResponse{}, resp)
+}
+
+func TestValidateVolumeCapabilities(t *testing.T) {
+	client := startController(t)
+
+	resp, err := client.ValidateVolumeCapabilities(context.Background(), &csi.ValidateVolumeCapabilitiesRequest{
+		VolumeId: "volumeID",
+		VolumeCapabilities: []*csi.VolumeCapability{
+			{
+				AccessType: &csi.VolumeCapability_Mount{},
+				AccessMode: &csi.VolumeCapability_AccessMode{},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetConfirmed())
+
+	resp, err = client.ValidateVolumeCapabilities(context.Background(), &csi.ValidateVolumeCapabilitiesRequest{
+		VolumeId: "volumeID",
+		VolumeCapabilities: []*csi.VolumeCapability{
+			{
+				AccessType: &csi.VolumeCapability_Block{},
+				AccessMode: &csi.VolumeCapability_AccessMode{},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, resp.GetConfirmed())
+	require.NotEmpty(t, resp.GetMessage())
+}
+
+func TestCreateVolumeTopology(t *testing.T) {
+	client, _ := startDriver(t, func(config *Config) {
+		config.Controller = true
+		config.PersistentVolumes = true
+		config.Topology = map[string]string{"topology.networkservicemesh.io/nsmgr": "true"}
+	})
+
+	selected := &csi.Topology{Segments: map[string]string{"topology.networkservicemesh.io/nsmgr": "true"}}
+	resp, err := client.CreateVolume(context.Background(), &csi.CreateVolumeRequest{
+		Name: "pvc-name",
+		VolumeCapabilities: []*csi.VolumeCapability{
+			{
+				AccessType: &csi.VolumeCapability_Mount{},
+				AccessMode: &csi.VolumeCapability_AccessMode{},
+			},
+		},
+		AccessibilityRequirements: &csi.TopologyRequirement{
+			Requisite: []*csi.Topology{selected},
+			Preferred: []*csi.Topology{selected},
+		},
+	})
+	require.NoError(t, err)
+	requireProtoEqual(t, []*csi.Topology{selected}, resp.GetVolume().GetAccessibleTopology())
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2023 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,21 +20,36 @@ package driver
 import (
 	"context"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/metric"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
-	"github.com/spiffe/spiffe-csi/pkg/mount"
 
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/audit"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/errorinfo"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/events"
 	"github.com/networkservicemesh/cmd-csi-driver/pkg/logkeys"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/mount"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
-type mountFunction func(string, string) error
-type unmountFunction func(string) error
-type isMountPointFunction func(string) (bool, error)
+const manifestFeaturesKey = "features"
+
+// Volume context keys of the pod info passed by the kubelet if the CSIDriver object sets podInfoOnMount
+const (
+	podNameKey           = "csi.storage.k8s.io/pod.name"
+	podNamespaceKey      = "csi.storage.k8s.io/pod.namespace"
+	podUIDKey            = "csi.storage.k8s.io/pod.uid"
+	podServiceAccountKey = "csi.storage.k8s.io/serviceAccount.name"
+)
+
+// ProbeFunc checks the health of a driver dependency, it is called by Probe
+type ProbeFunc func(ctx context.Context) error
 
 // Config is the configuration for the driver
 type Config struct {
@@ -43,25 +58,83 @@ type Config struct {
 	PluginName   string
 	Version      string
 	NSMSocketDir string
-
-	customMount   mountFunction
-	customUnmount unmountFunction
+	// TargetDirMode is the mode of created target directories, 0750 if unset
+	TargetDirMode os.FileMode
+	// TargetDirUID is the owner of created target directories, the driver user if nil
+	TargetDirUID *int
+	// TargetDirGID is the group of created target directories, the driver group if nil
+	TargetDirGID *int
+	// PersistentVolumes enables non-ephemeral volumes staged with NodeStageVolume
+	PersistentVolumes bool
+	// Controller enables the controller service provisioning generic ephemeral volumes
+	Controller bool
+	// Topology is the set of segments the node reports as its accessible topology
+	Topology map[string]string
+	// MaxVolumesPerNode is the maximum number of volumes the node can publish, 0 is unlimited
+	MaxVolumesPerNode int64
+	// Manifest is additional plugin metadata, e.g. build information, returned by GetPluginInfo
+	Manifest map[string]string
+	// Probes are the health checks run by Probe
+	Probes []ProbeFunc
+	// AuditLog records the publish and unpublish attempts if not nil
+	AuditLog *audit.Log
+	// Events reports the publish failures as events on the pods if not nil
+	Events *events.Recorder
+	// Mounter mounts the volumes, the bind mounts of the node if nil
+	Mounter mount.Mounter
+	// OperationTimeout bounds the mount, unmount and volume health operations in addition to the RPC deadline, 0 is
+	// unbounded
+	OperationTimeout time.Duration
+	// UnmountRetries is the number of retries of an unmount failing because the target is busy
+	UnmountRetries int
+	// UnmountRetryInterval is the delay before the first unmount retry, doubled for each further retry
+	UnmountRetryInterval time.Duration
+	// LazyUnmount detaches a target still busy after the unmount retries
+	LazyUnmount bool
+	// KubeletPodsDir is the kubelet pods directory the orphaned volumes are collected from, /var/lib/kubelet/pods if
+	// unset
+	KubeletPodsDir string
+	// PodUIDs lists the pods the kubelet runs, the garbage collector only checks the pod directories if nil
+	PodUIDs PodUIDsFunc
+	// GCDryRun makes the garbage collector only report the orphaned volumes
+	GCDryRun bool
+	// NSMSocketName is the name of the NSM API socket in NSMSocketDir, nsm.io.sock if unset
+	NSMSocketName string
+	// NSMSocketWaitTimeout is how long publishing waits for the NSM API socket to accept connections, 0 disables the
+	// wait
+	NSMSocketWaitTimeout time.Duration
+	// MeterProvider provides the meter of the driver metrics, the global OpenTelemetry meter provider if nil
+	MeterProvider metric.MeterProvider
 }
 
-// Driver is the ephemeral-inline CSI driver implementation
+// Driver is the CSI driver implementation serving ephemeral-inline and, if enabled, persistent volumes
 type Driver struct {
 	csi.UnimplementedIdentityServer
 	csi.UnimplementedNodeServer
+	csi.UnimplementedControllerServer
 
 	logger       log.Logger
 	nodeID       string
 	pluginName   string
 	version      string
 	nsmSocketDir string
-
-	mount        mountFunction
-	unmount      unmountFunction
-	isMountPoint isMountPointFunction
+	nsmSocket    nsmSocketOptions
+	targetDir    targetDirOptions
+	persistent   bool
+	controller   bool
+	topology     map[string]string
+	maxVolumes   int64
+	manifest     map[string]string
+	probes       []ProbeFunc
+	auditLog     *audit.Log
+	events       *events.Recorder
+	mounter      mount.Mounter
+	ops          *operations
+	unmountOpts  unmountOptions
+	locks        *operationLocks
+	gc           *garbageCollector
+	// stepHook is called before each publish and stage step, the tests inject step failures with it
+	stepHook func(step string) error
 }
 
 // New creates a new driver with the given config
@@ -71,6 +144,23 @@ func New(config *Config) (*Driver, error) {
 		return nil, errors.New("node ID is required")
 	case config.NSMSocketDir == "":
 		return nil, errors.New("network service API socket directory is required")
+	case config.TargetDirMode&^os.ModePerm != 0:
+		return nil, errors.Errorf("target directory mode %#o is not a permission mode", uint32(config.TargetDirMode))
+	case config.Controller && !config.PersistentVolumes:
+		return nil, errors.New("controller requires persistent volumes, its volumes are staged and published like them")
+	case config.MaxVolumesPerNode < 0:
+		return nil, errors.New("max volumes per node must not be negative")
+	case config.OperationTimeout < 0:
+		return nil, errors.New("operation timeout must not be negative")
+	case config.UnmountRetries < 0 || config.UnmountRetryInterval < 0:
+		return nil, errors.New("unmount retries and retry interval must not be negative")
+	case config.NSMSocketWaitTimeout < 0:
+		return nil, errors.New("NSM socket wait timeout must not be negative")
+	}
+	for key, value := range config.Topology {
+		if key == "" || value == "" {
+			return nil, errors.Errorf("invalid topology segment %q=%q", key, value)
+		}
 	}
 	d := &Driver{
 		logger:       config.Log,
@@ -78,20 +168,53 @@ func New(config *Config) (*Driver, error) {
 		pluginName:   config.PluginName,
 		version:      config.Version,
 		nsmSocketDir: config.NSMSocketDir,
-		mount:        mount.BindMountRW,
-		unmount:      mount.Unmount,
-		isMountPoint: mount.IsMountPoint,
+		nsmSocket:    newNSMSocketOptions(config),
+		targetDir:    newTargetDirOptions(config),
+		persistent:   config.PersistentVolumes,
+		controller:   config.Controller,
+		topology:     config.Topology,
+		maxVolumes:   config.MaxVolumesPerNode,
+		probes:       config.Probes,
+		auditLog:     config.AuditLog,
+		events:       config.Events,
+		mounter:      config.Mounter,
+		ops:          newOperations(config.Log, config.OperationTimeout),
+		unmountOpts:  newUnmountOptions(config),
+		locks:        newOperationLocks(),
 	}
-	if config.customMount != nil {
-		d.mount = config.customMount
+	d.manifest = d.buildManifest(config.Manifest)
+	gc, err := newGarbageCollector(config)
+	if err != nil {
+		return nil, err
 	}
-	if config.customUnmount != nil {
-		d.unmount = config.customUnmount
+	d.gc = gc
+	if d.mounter == nil {
+		d.mounter = mount.New()
 	}
 
 	return d, nil
 }
 
+// buildManifest adds the enabled feature modes to the given plugin metadata
+func (d *Driver) buildManifest(metadata map[string]string) map[string]string {
+	manifest := make(map[string]string, len(metadata)+1)
+	for key, value := range metadata {
+		manifest[key] = value
+	}
+	features := []string{"ephemeral"}
+	if d.persistent {
+		features = append(features, "persistent")
+	}
+	if d.controller {
+		features = append(features, "controller")
+	}
+	if len(d.topology) > 0 {
+		features = append(features, "topology")
+	}
+	manifest[manifestFeaturesKey] = strings.Join(features, ",")
+	return manifest
+}
+
 /////////////////////////////////////////////////////////////////////////////
 // Identity Server
 /////////////////////////////////////////////////////////////////////////////
@@ -101,17 +224,41 @@ func (d *Driver) GetPluginInfo(_ context.Context, _ *csi.GetPluginInfoRequest) (
 	return &csi.GetPluginInfoResponse{
 		Name:          d.pluginName,
 		VendorVersion: d.version,
+		Manifest:      d.manifest,
 	}, nil
 }
 
 // GetPluginCapabilities returns plugin capabilities
 func (d *Driver) GetPluginCapabilities(_ context.Context, _ *csi.GetPluginCapabilitiesRequest) (*csi.GetPluginCapabilitiesResponse, error) {
-	// Only the Node server is implemented. No other capabilities are available.
-	return &csi.GetPluginCapabilitiesResponse{}, nil
+	resp := &csi.GetPluginCapabilitiesResponse{}
+	if d.controller {
+		resp.Capabilities = append(resp.Capabilities, &csi.PluginCapability{
+			Type: &csi.PluginCapability_Service_{
+				Service: &csi.PluginCapability_Service{
+					Type: csi.PluginCapability_Service_CONTROLLER_SERVICE,
+				},
+			},
+		})
+	}
+	if len(d.topology) > 0 {
+		resp.Capabilities = append(resp.Capabilities, &csi.PluginCapability{
+			Type: &csi.PluginCapability_Service_{
+				Service: &csi.PluginCapability_Service{
+					Type: csi.PluginCapability_Service_VOLUME_ACCESSIBILITY_CONSTRAINTS,
+				},
+			},
+		})
+	}
+	return resp, nil
 }
 
 // Probe verifies that the plugin is in a healthy state
-func (d *Driver) Probe(_ context.Context, _ *csi.ProbeRequest) (*csi.ProbeResponse, error) {
+func (d *Driver) Probe(ctx context.Context, _ *csi.ProbeRequest) (*csi.ProbeResponse, error) {
+	for _, probe := range d.probes {
+		if err := probe(ctx); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "probe failed: %v", err)
+		}
+	}
 	return &csi.ProbeResponse{}, nil
 }
 
@@ -120,21 +267,24 @@ func (d *Driver) Probe(_ context.Context, _ *csi.ProbeRequest) (*csi.ProbeRespon
 /////////////////////////////////////////////////////////////////////////////
 
 // NodePublishVolume is called when a workload that wants to use the specified volume is placed (scheduled) on a node
-func (d *Driver) NodePublishVolume(_ context.Context, req *csi.NodePublishVolumeRequest) (_ *csi.NodePublishVolumeResponse, err error) {
+func (d *Driver) NodePublishVolume(ctx context.Context, req *csi.NodePublishVolumeRequest) (_ *csi.NodePublishVolumeResponse, err error) {
 	ephemeralMode := req.GetVolumeContext()["csi.storage.k8s.io/ephemeral"]
 
-	logger := d.logger.
-		WithField(logkeys.VolumeID, req.VolumeId).
-		WithField(logkeys.TargetPath, req.TargetPath)
+	logger := d.requestLogger(ctx, req)
 
 	if req.VolumeCapability != nil && req.VolumeCapability.AccessMode != nil {
 		logger = logger.WithField("access_mode", req.VolumeCapability.AccessMode.Mode)
 	}
 
 	defer func() {
+		err = d.withErrorInfo(err)
 		if err != nil {
 			logger.Error(err, "Failed to publish volume")
 		}
+		d.audit(logger, audit.ActionPublish, req.VolumeId, req.TargetPath, req.GetVolumeContext(), err)
+		if err != nil {
+			d.reportPublishFailure(logger, req.GetVolumeContext(), err)
+		}
 	}()
 
 	// Validate request
@@ -143,25 +293,48 @@ func (d *Driver) NodePublishVolume(_ context.Context, req *csi.NodePublishVolume
 		return nil, status.Error(codes.InvalidArgument, "request missing required volume id")
 	case req.TargetPath == "":
 		return nil, status.Error(codes.InvalidArgument, "request missing required target path")
-	case req.VolumeCapability == nil:
-		return nil, status.Error(codes.InvalidArgument, "request missing required volume capability")
-	case req.VolumeCapability.AccessType == nil:
-		return nil, status.Error(codes.InvalidArgument, "request missing required volume capability access type")
-	case !isVolumeCapabilityPlainMount(req.VolumeCapability):
-		return nil, status.Error(codes.InvalidArgument, "request volume capability access type must be a simple mount")
-	case req.VolumeCapability.AccessMode == nil:
-		return nil, status.Error(codes.InvalidArgument, "request missing required volume capability access mode")
-	case isVolumeCapabilityAccessModeReadOnly(req.VolumeCapability.AccessMode):
-		return nil, status.Error(codes.InvalidArgument, "request volume capability access mode is not valid")
+	}
+	if err := validateVolumeCapability(req.VolumeCapability); err != nil {
+		return nil, err
+	}
+	switch {
 	case !req.Readonly:
 		return nil, status.Error(codes.InvalidArgument, "pod.spec.volumes[].csi.readOnly must be set to 'true'")
-	case ephemeralMode != "true":
+	case ephemeralMode != "true" && !d.persistent:
 		return nil, status.Error(codes.InvalidArgument, "only ephemeral volumes are supported")
+	case ephemeralMode != "true" && req.StagingTargetPath == "":
+		return nil, status.Error(codes.InvalidArgument, "request missing required staging target path")
+	}
+
+	unlock, err := d.lockOperation(req.VolumeId, req.TargetPath)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// The completed steps are undone if a later one fails
+	steps := d.newRollback(logger)
+	defer func() {
+		if err != nil {
+			steps.undo()
+		}
+	}()
+
+	source, err := d.publishSource(ephemeralMode == "true", req.StagingTargetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.alreadyPublished(logger, req.TargetPath) {
+		return &csi.NodePublishVolumeResponse{}, nil
+	}
+
+	if err := d.waitNSMSocket(ctx); err != nil {
+		return nil, err
 	}
 
-	// Create the target path (required by CSI interface)
-	if err := os.Mkdir(req.TargetPath, 0o750); err != nil && !os.IsExist(err) {
-		return nil, status.Errorf(codes.Internal, "unable to create target path %q: %v", req.TargetPath, err)
+	if err := d.createTargetPath(steps, req.TargetPath, req.GetVolumeContext()); err != nil {
+		return nil, err
 	}
 
 	// Ideally the volume is writable by the host to enable, for example,
@@ -169,8 +342,12 @@ func (d *Driver) NodePublishVolume(_ context.Context, req *csi.NodePublishVolume
 	// be writable by workload containers. We enforce that the CSI volume is
 	// marked read-only above, instructing the kubelet to mount it read-only
 	// into containers, while we mount the volume read-write to the host.
-	if err := d.mount(d.nsmSocketDir, req.TargetPath); err != nil {
-		return nil, status.Errorf(codes.Internal, "unable to mount %q: %v", req.TargetPath, err)
+	if err := steps.do(stepMount, func() error {
+		return d.mount(ctx, source, req.TargetPath)
+	}, func() error {
+		return d.unmount(context.WithoutCancel(ctx), logger, req.TargetPath)
+	}); err != nil {
+		return nil, fsError(err, errorinfo.MountFailed, req.TargetPath, "unable to mount %q", req.TargetPath)
 	}
 
 	logger.Info("Volume published")
@@ -178,16 +355,73 @@ func (d *Driver) NodePublishVolume(_ context.Context, req *csi.NodePublishVolume
 	return &csi.NodePublishVolumeResponse{}, nil
 }
 
+// publishSource returns the path the volume is mounted from. Ephemeral volumes are mounted straight from the NSM socket
+// directory, persistent volumes are mounted from their node-global staging path.
+func (d *Driver) publishSource(ephemeral bool, stagingTargetPath string) (string, error) {
+	if !ephemeral {
+		if err := d.checkStaged(stagingTargetPath); err != nil {
+			return "", err
+		}
+		return stagingTargetPath, nil
+	}
+	if err := d.checkNSMSocketDir(); err != nil {
+		return "", err
+	}
+	return d.nsmSocketDir, nil
+}
+
+// alreadyPublished checks whether the volume is already published at targetPath, as the kubelet retries a publish it
+// gave up on, which may have completed meanwhile
+func (d *Driver) alreadyPublished(logger log.Logger, targetPath string) bool {
+	if ok, err := d.isPublished(targetPath); err != nil || !ok {
+		return false
+	}
+	logger.Info("Volume already published")
+	return true
+}
+
+// createTargetPath creates the target path (required by CSI interface) with the mode and the owner of the volume
+// context
+func (d *Driver) createTargetPath(steps *rollback, targetPath string, volumeContext map[string]string) error {
+	targetDir, err := d.targetDir.withVolumeContext(volumeContext)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	created := false
+	if err := steps.do(stepCreateTargetPath, func() (createErr error) {
+		created, createErr = targetDir.create(targetPath)
+		return createErr
+	}, func() error {
+		// A target path created by the kubelet is left in place, as is one an abandoned mount may still complete on
+		if !created {
+			return nil
+		}
+		if err := d.ops.check(targetPath); err != nil {
+			return err
+		}
+		return os.Remove(targetPath)
+	}); err != nil {
+		return fsError(err, errorinfo.PathFailed, targetPath, "")
+	}
+	if err := steps.do(stepSetTargetPath, func() error {
+		return targetDir.apply(targetPath)
+	}, nil); err != nil {
+		return fsError(err, errorinfo.PathFailed, targetPath, "")
+	}
+	return nil
+}
+
 // NodeUnpublishVolume is a reverse operation of NodePublishVolume
-func (d *Driver) NodeUnpublishVolume(_ context.Context, req *csi.NodeUnpublishVolumeRequest) (_ *csi.NodeUnpublishVolumeResponse, err error) {
-	logger := d.logger.
-		WithField(logkeys.VolumeID, req.VolumeId).
-		WithField(logkeys.TargetPath, req.TargetPath)
+func (d *Driver) NodeUnpublishVolume(ctx context.Context, req *csi.NodeUnpublishVolumeRequest) (_ *csi.NodeUnpublishVolumeResponse, err error) {
+	logger := d.requestLogger(ctx, req)
 
 	defer func() {
+		err = d.withErrorInfo(err)
 		if err != nil {
 			logger.Error(err, "Failed to unpublish volume")
 		}
+		d.audit(logger, audit.ActionUnpublish, req.VolumeId, req.TargetPath, nil, err)
 	}()
 
 	// Validate request
@@ -198,11 +432,27 @@ func (d *Driver) NodeUnpublishVolume(_ context.Context, req *csi.NodeUnpublishVo
 		return nil, status.Error(codes.InvalidArgument, "request missing required target path")
 	}
 
-	if err := d.unmount(req.TargetPath); err != nil {
-		return nil, status.Errorf(codes.Internal, "unable to unmount %q: %v", req.TargetPath, err)
+	unlock, err := d.lockOperation(req.VolumeId, req.TargetPath)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := d.ops.check(req.TargetPath); err != nil {
+		return nil, err
+	}
+
+	// Unpublishing must be idempotent, the kubelet retries it until it succeeds
+	if _, err := os.Stat(req.TargetPath); os.IsNotExist(err) {
+		logger.Info("Volume already unpublished")
+		return &csi.NodeUnpublishVolumeResponse{}, nil
+	}
+
+	if err := d.unmountAll(ctx, logger, req.TargetPath); err != nil {
+		return nil, err
 	}
 	if err := os.Remove(req.TargetPath); err != nil {
-		return nil, status.Errorf(codes.Internal, "unable to remove target path %q: %v", req.TargetPath, err)
+		return nil, fsError(err, errorinfo.PathFailed, req.TargetPath, "unable to remove target path %q", req.TargetPath)
 	}
 
 	logger.Info("Volume unpublished")
@@ -210,9 +460,138 @@ func (d *Driver) NodeUnpublishVolume(_ context.Context, req *csi.NodeUnpublishVo
 	return &csi.NodeUnpublishVolumeResponse{}, nil
 }
 
+// NodeStageVolume mounts the NSM socket directory to the node-global staging path of a persistent volume
+func (d *Driver) NodeStageVolume(ctx context.Context, req *csi.NodeStageVolumeRequest) (_ *csi.NodeStageVolumeResponse, err error) {
+	logger := d.requestLogger(ctx, req)
+
+	defer func() {
+		err = d.withErrorInfo(err)
+		if err != nil {
+			logger.Error(err, "Failed to stage volume")
+		}
+	}()
+
+	// Validate request
+	switch {
+	case !d.persistent:
+		return nil, status.Error(codes.Unimplemented, "persistent volumes are not enabled")
+	case req.VolumeId == "":
+		return nil, status.Error(codes.InvalidArgument, "request missing required volume id")
+	case req.StagingTargetPath == "":
+		return nil, status.Error(codes.InvalidArgument, "request missing required staging target path")
+	}
+	if err := validateVolumeCapability(req.VolumeCapability); err != nil {
+		return nil, err
+	}
+
+	unlock, err := d.lockOperation(req.VolumeId, req.StagingTargetPath)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// The completed steps are undone if a later one fails
+	steps := d.newRollback(logger)
+	defer func() {
+		if err != nil {
+			steps.undo()
+		}
+	}()
+
+	created := false
+	if err := steps.do(stepCreateStaging, func() error {
+		mkdirErr := os.Mkdir(req.StagingTargetPath, 0o750)
+		created = mkdirErr == nil
+		if mkdirErr != nil && !os.IsExist(mkdirErr) {
+			return mkdirErr
+		}
+		return nil
+	}, func() error {
+		if !created {
+			return nil
+		}
+		if err := d.ops.check(req.StagingTargetPath); err != nil {
+			return err
+		}
+		return os.Remove(req.StagingTargetPath)
+	}); err != nil {
+		return nil, fsError(err, errorinfo.PathFailed, req.StagingTargetPath, "unable to create staging target path %q", req.StagingTargetPath)
+	}
+
+	// The staging path is shared by all pods using the volume, so staging must be idempotent
+	if ok, err := d.mounter.IsMountPoint(req.StagingTargetPath); err == nil && ok {
+		logger.Info("Volume already staged")
+		return &csi.NodeStageVolumeResponse{}, nil
+	}
+
+	if err := d.checkNSMSocketDir(); err != nil {
+		return nil, err
+	}
+	if err := steps.do(stepMount, func() error {
+		return d.mount(ctx, d.nsmSocketDir, req.StagingTargetPath)
+	}, func() error {
+		return d.unmount(context.WithoutCancel(ctx), logger, req.StagingTargetPath)
+	}); err != nil {
+		return nil, fsError(err, errorinfo.MountFailed, req.StagingTargetPath, "unable to mount %q", req.StagingTargetPath)
+	}
+
+	logger.Info("Volume staged")
+
+	return &csi.NodeStageVolumeResponse{}, nil
+}
+
+// NodeUnstageVolume is a reverse operation of NodeStageVolume
+func (d *Driver) NodeUnstageVolume(ctx context.Context, req *csi.NodeUnstageVolumeRequest) (_ *csi.NodeUnstageVolumeResponse, err error) {
+	logger := d.requestLogger(ctx, req)
+
+	defer func() {
+		err = d.withErrorInfo(err)
+		if err != nil {
+			logger.Error(err, "Failed to unstage volume")
+		}
+	}()
+
+	// Validate request
+	switch {
+	case !d.persistent:
+		return nil, status.Error(codes.Unimplemented, "persistent volumes are not enabled")
+	case req.VolumeId == "":
+		return nil, status.Error(codes.InvalidArgument, "request missing required volume id")
+	case req.StagingTargetPath == "":
+		return nil, status.Error(codes.InvalidArgument, "request missing required staging target path")
+	}
+
+	unlock, err := d.lockOperation(req.VolumeId, req.StagingTargetPath)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := d.ops.check(req.StagingTargetPath); err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(req.StagingTargetPath); os.IsNotExist(err) {
+		logger.Info("Volume already unstaged")
+		return &csi.NodeUnstageVolumeResponse{}, nil
+	}
+
+	// The staging path may exist unmounted, e.g. after a node reboot or a stage failing after creating it
+	if err := d.unmountAll(ctx, logger, req.StagingTargetPath); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(req.StagingTargetPath); err != nil {
+		return nil, fsError(err, errorinfo.PathFailed, req.StagingTargetPath, "unable to remove staging target path %q", req.StagingTargetPath)
+	}
+
+	logger.Info("Volume unstaged")
+
+	return &csi.NodeUnstageVolumeResponse{}, nil
+}
+
 // NodeGetCapabilities allows to check the supported capabilities of node service provided by the Plugin
 func (d *Driver) NodeGetCapabilities(_ context.Context, _ *csi.NodeGetCapabilitiesRequest) (*csi.NodeGetCapabilitiesResponse, error) {
-	return &csi.NodeGetCapabilitiesResponse{
+	resp := &csi.NodeGetCapabilitiesResponse{
 		Capabilities: []*csi.NodeServiceCapability{
 			{
 				Type: &csi.NodeServiceCapability_Rpc{
@@ -229,56 +608,170 @@ func (d *Driver) NodeGetCapabilities(_ context.Context, _ *csi.NodeGetCapabiliti
 				},
 			},
 		},
-	}, nil
+	}
+	if d.persistent {
+		resp.Capabilities = append(resp.Capabilities, &csi.NodeServiceCapability{
+			Type: &csi.NodeServiceCapability_Rpc{
+				Rpc: &csi.NodeServiceCapability_RPC{
+					Type: csi.NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME,
+				},
+			},
+		})
+	}
+	return resp, nil
 }
 
-// NodeGetInfo returns the node identifier
+// NodeGetInfo returns the node identifier, its accessible topology and volume limit
 func (d *Driver) NodeGetInfo(_ context.Context, _ *csi.NodeGetInfoRequest) (*csi.NodeGetInfoResponse, error) {
-	return &csi.NodeGetInfoResponse{
+	resp := &csi.NodeGetInfoResponse{
 		NodeId:            d.nodeID,
-		MaxVolumesPerNode: 0,
-	}, nil
+		MaxVolumesPerNode: d.maxVolumes,
+	}
+	if len(d.topology) > 0 {
+		resp.AccessibleTopology = &csi.Topology{
+			Segments: d.topology,
+		}
+	}
+	return resp, nil
 }
 
 // NodeGetVolumeStats returns the volume capacity statistics available for the volume.
-func (d *Driver) NodeGetVolumeStats(_ context.Context, req *csi.NodeGetVolumeStatsRequest) (*csi.NodeGetVolumeStatsResponse, error) {
-	logger := d.logger.
-		WithField(logkeys.VolumeID, req.VolumeId).
-		WithField(logkeys.VolumePath, req.VolumePath)
-
-	volumeConditionAbnormal := false
-	volumeConditionMessage := "mounted"
-	if err := d.checkNsAPIMount(req.VolumePath); err != nil {
-		volumeConditionAbnormal = true
-		volumeConditionMessage = err.Error()
-		logger.Error(err, "Volume is unhealthy")
-	} else {
-		logger.Info("Volume is healthy")
+func (d *Driver) NodeGetVolumeStats(ctx context.Context, req *csi.NodeGetVolumeStatsRequest) (_ *csi.NodeGetVolumeStatsResponse, err error) {
+	logger := d.requestLogger(ctx, req)
+
+	defer func() { err = d.withErrorInfo(err) }()
+
+	// Validate request
+	switch {
+	case req.VolumeId == "":
+		return nil, status.Error(codes.InvalidArgument, "request missing required volume id")
+	case req.VolumePath == "":
+		return nil, status.Error(codes.InvalidArgument, "request missing required volume path")
+	}
+	err = d.ops.run(ctx, "stat", req.VolumePath, func() error {
+		_, err := os.Stat(req.VolumePath)
+		return err
+	})
+	switch {
+	case os.IsNotExist(err):
+		return nil, newNodeError(codes.NotFound, errorinfo.PathNotFound, req.VolumePath, "volume path %q does not exist", req.VolumePath)
+	case status.Code(err) == codes.DeadlineExceeded:
+		return nil, err
+	}
+
+	// The driver keeps no state, so the volume is known if it is mounted to the volume path
+	mounted, err := d.mounter.IsMountPoint(req.VolumePath)
+	switch {
+	case err != nil:
+		return nil, fsError(err, errorinfo.PathFailed, req.VolumePath, "unable to check volume path %q", req.VolumePath)
+	case !mounted:
+		return nil, newNodeError(codes.NotFound, errorinfo.VolumeNotPublished, req.VolumePath, "volume %q is not published at %q", req.VolumeId, req.VolumePath)
 	}
 
-	return &csi.NodeGetVolumeStatsResponse{
+	resp := &csi.NodeGetVolumeStatsResponse{
 		VolumeCondition: &csi.VolumeCondition{
-			Abnormal: volumeConditionAbnormal,
-			Message:  volumeConditionMessage,
+			Message: "mounted",
 		},
-	}, nil
+	}
+	// Listing and statfs hang on a broken FUSE or NFS parent of the NSM socket directory
+	var usage []*csi.VolumeUsage
+	err = d.ops.run(ctx, "health check", req.VolumePath, func() error {
+		if err := checkNsAPIMount(req.VolumePath); err != nil {
+			return err
+		}
+		var usageErr error
+		if usage, usageErr = volumeUsage(req.VolumePath); usageErr != nil {
+			logger.Warnf("Unable to report volume usage: %v", usageErr)
+		}
+		return nil
+	})
+	switch {
+	case status.Code(err) == codes.DeadlineExceeded:
+		return nil, err
+	case err != nil:
+		resp.VolumeCondition.Abnormal = true
+		resp.VolumeCondition.Message = err.Error()
+		logger.Error(err, "Volume is unhealthy")
+		return resp, nil
+	}
+	resp.Usage = usage
+	logger.Debug("Volume is healthy")
+
+	return resp, nil
 }
 
-func (d *Driver) checkNsAPIMount(volumePath string) error {
-	// Check whether it is a mount point.
-	if ok, err := d.isMountPoint(volumePath); err != nil {
-		return errors.Errorf("failed to determine root for volume path mount: %v", err)
-	} else if !ok {
-		return errors.New("volume path is not mounted")
-	}
-	// If a mount point, try to list files... this should fail if the mount is
-	// broken for whatever reason.
+// checkNsAPIMount checks the mount of the volume path isn't broken
+func checkNsAPIMount(volumePath string) error {
+	// Try to list files... this should fail if the mount is broken for whatever reason.
 	if _, err := os.ReadDir(volumePath); err != nil {
 		return errors.Errorf("unable to list contents of volume path: %v", err)
 	}
 	return nil
 }
 
+// requestLogger returns the request-scoped logger propagated through ctx by the server interceptors. Without one,
+// e.g. if the driver is served without the interceptors of pkg/server, the driver logger is used.
+func (d *Driver) requestLogger(ctx context.Context, req interface{}) log.Logger {
+	if logger := log.FromContext(ctx); logger != log.L() {
+		return logger
+	}
+	return logkeys.WithRequestFields(d.logger, req)
+}
+
+// checkNSMSocketDir fails with Unavailable if the NSM socket directory is missing, e.g. NSM is not running on the
+// node, as the kubelet retries the RPC until it is
+func (d *Driver) checkNSMSocketDir() error {
+	if _, err := os.Stat(d.nsmSocketDir); err != nil {
+		return newNodeError(codes.Unavailable, errorinfo.NSMSocketDirMissing, d.nsmSocketDir, "NSM socket directory %q is missing: %v", d.nsmSocketDir, err)
+	}
+	return nil
+}
+
+// checkStaged verifies that the staging path of a persistent volume is mounted
+func (d *Driver) checkStaged(stagingTargetPath string) error {
+	ok, err := d.mounter.IsMountPoint(stagingTargetPath)
+	switch {
+	case err != nil:
+		return newNodeError(codes.FailedPrecondition, errorinfo.VolumeNotStaged, stagingTargetPath, "unable to check staging target path %q: %v", stagingTargetPath, err)
+	case !ok:
+		return newNodeError(codes.FailedPrecondition, errorinfo.VolumeNotStaged, stagingTargetPath, "volume is not staged at %q", stagingTargetPath)
+	}
+	return nil
+}
+
+// isPublished checks whether the top mount of targetPath is a mount of the NSM socket directory
+func (d *Driver) isPublished(targetPath string) (bool, error) {
+	if ok, err := d.mounter.IsMountPoint(targetPath); err != nil || !ok {
+		return false, err
+	}
+	mountPoints, err := d.mounter.List()
+	if err != nil {
+		return false, err
+	}
+	for i := len(mountPoints) - 1; i >= 0; i-- {
+		if mountPoints[i].Target == targetPath {
+			return mountPoints[i].Root == socketDirRoot(mountPoints, d.nsmSocketDir), nil
+		}
+	}
+	return false, nil
+}
+
+func validateVolumeCapability(volumeCapability *csi.VolumeCapability) error {
+	switch {
+	case volumeCapability == nil:
+		return status.Error(codes.InvalidArgument, "request missing required volume capability")
+	case volumeCapability.AccessType == nil:
+		return status.Error(codes.InvalidArgument, "request missing required volume capability access type")
+	case !isVolumeCapabilityPlainMount(volumeCapability):
+		return status.Error(codes.InvalidArgument, "request volume capability access type must be a simple mount")
+	case volumeCapability.AccessMode == nil:
+		return status.Error(codes.InvalidArgument, "request missing required volume capability access mode")
+	case isVolumeCapabilityAccessModeReadOnly(volumeCapability.AccessMode):
+		return status.Error(codes.InvalidArgument, "request volume capability access mode is not valid")
+	}
+	return nil
+}
+
 func isVolumeCapabilityPlainMount(volumeCapability *csi.VolumeCapability) bool {
 	m := volumeCapability.GetMount()
 	switch {
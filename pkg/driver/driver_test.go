@@ -1,4 +1,4 @@
-// Copyright (c) 2023 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -24,11 +24,14 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -36,6 +39,9 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 
+	"github.com/networkservicemesh/cmd-csi-driver/internal/nstest"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/mount"
+
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
@@ -43,40 +49,36 @@ const (
 	testNodeID = "nodeID"
 )
 
-func bindMountRWTest(src, dst string) error {
-	return writeMeta(dst, src)
-}
-func unmountTest(dst string) error {
-	return os.Remove(metaPath(dst))
-}
-
 func TestNew(t *testing.T) {
 	nsmSocketDir := t.TempDir()
 
 	t.Run("node ID is required", func(t *testing.T) {
 		_, err := New(&Config{
-			NSMSocketDir:  nsmSocketDir,
-			customMount:   bindMountRWTest,
-			customUnmount: unmountTest,
+			NSMSocketDir: nsmSocketDir,
 		})
 		require.EqualError(t, err, "node ID is required")
 	})
 
 	t.Run("network service API socket directory is required", func(t *testing.T) {
 		_, err := New(&Config{
-			NodeID:        testNodeID,
-			customMount:   bindMountRWTest,
-			customUnmount: unmountTest,
+			NodeID: testNodeID,
 		})
 		require.EqualError(t, err, "network service API socket directory is required")
 	})
 
+	t.Run("controller requires persistent volumes", func(t *testing.T) {
+		_, err := New(&Config{
+			NodeID:       testNodeID,
+			NSMSocketDir: nsmSocketDir,
+			Controller:   true,
+		})
+		require.EqualError(t, err, "controller requires persistent volumes, its volumes are staged and published like them")
+	})
+
 	t.Run("success", func(t *testing.T) {
 		_, err := New(&Config{
-			NodeID:        testNodeID,
-			NSMSocketDir:  nsmSocketDir,
-			customMount:   bindMountRWTest,
-			customUnmount: unmountTest,
+			NodeID:       testNodeID,
+			NSMSocketDir: nsmSocketDir,
 		})
 		require.NoError(t, err)
 	})
@@ -91,6 +93,9 @@ func TestBoilerplateRPCs(t *testing.T) {
 		requireProtoEqual(t, &csi.GetPluginInfoResponse{
 			Name:          "csi.networkservicemesh.io",
 			VendorVersion: "v1.0.0",
+			Manifest: map[string]string{
+				"features": "ephemeral",
+			},
 		}, resp, "unexpected response")
 	})
 
@@ -139,6 +144,67 @@ func TestBoilerplateRPCs(t *testing.T) {
 	})
 }
 
+func TestProbe(t *testing.T) {
+	client, _ := startDriver(t, func(config *Config) {
+		config.Probes = []ProbeFunc{
+			func(context.Context) error { return nil },
+			func(context.Context) error { return errors.New("not registered") },
+		}
+	})
+
+	_, err := client.Probe(context.Background(), &csi.ProbeRequest{})
+	requireGRPCStatusPrefix(t, err, codes.FailedPrecondition, "probe failed: not registered")
+}
+
+func TestTopology(t *testing.T) {
+	topology := map[string]string{"topology.networkservicemesh.io/nsmgr": "true"}
+	client, _ := startDriver(t, func(config *Config) {
+		config.Topology = topology
+		config.MaxVolumesPerNode = 10
+	})
+
+	t.Run("GetPluginCapabilities", func(t *testing.T) {
+		resp, err := client.GetPluginCapabilities(context.Background(), &csi.GetPluginCapabilitiesRequest{})
+		require.NoError(t, err)
+		requireProtoEqual(t, &csi.GetPluginCapabilitiesResponse{
+			Capabilities: []*csi.PluginCapability{
+				{
+					Type: &csi.PluginCapability_Service_{
+						Service: &csi.PluginCapability_Service{
+							Type: csi.PluginCapability_Service_VOLUME_ACCESSIBILITY_CONSTRAINTS,
+						},
+					},
+				},
+			},
+		}, resp, "unexpected response")
+	})
+
+	t.Run("NodeGetInfo", func(t *testing.T) {
+		resp, err := client.NodeGetInfo(context.Background(), &csi.NodeGetInfoRequest{})
+		require.NoError(t, err)
+		requireProtoEqual(t, &csi.NodeGetInfoResponse{
+			NodeId:             testNodeID,
+			MaxVolumesPerNode:  10,
+			AccessibleTopology: &csi.Topology{Segments: topology},
+		}, resp, "unexpected response")
+	})
+
+	t.Run("GetPluginInfo", func(t *testing.T) {
+		resp, err := client.GetPluginInfo(context.Background(), &csi.GetPluginInfoRequest{})
+		require.NoError(t, err)
+		require.Equal(t, "ephemeral,topology", resp.GetManifest()["features"])
+	})
+
+	t.Run("invalid topology", func(t *testing.T) {
+		_, err := New(&Config{
+			NodeID:       testNodeID,
+			NSMSocketDir: t.TempDir(),
+			Topology:     map[string]string{"topology.networkservicemesh.io/nsmgr": ""},
+		})
+		require.Error(t, err)
+	})
+}
+
 func TestNodePublishVolume(t *testing.T) {
 	for _, tt := range []struct {
 		desc            string
@@ -235,6 +301,22 @@ func TestNodePublishVolume(t *testing.T) {
 			expectCode:      codes.InvalidArgument,
 			expectMsgPrefix: "only ephemeral volumes are supported",
 		},
+		{
+			desc: "invalid target dir mode attribute",
+			mutateReq: func(req *csi.NodePublishVolumeRequest) {
+				req.VolumeContext[TargetDirModeAttribute] = "0999"
+			},
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: `invalid volume attribute "targetDirMode"`,
+		},
+		{
+			desc: "invalid target dir UID attribute",
+			mutateReq: func(req *csi.NodePublishVolumeRequest) {
+				req.VolumeContext[TargetDirUIDAttribute] = "-5"
+			},
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: `invalid volume attribute "targetDirUID"`,
+		},
 		{
 			desc: "target path already exists",
 			mungeTargetPath: func(t *testing.T, targetPath string) {
@@ -250,7 +332,7 @@ func TestNodePublishVolume(t *testing.T) {
 				// will exist).
 				require.NoError(t, os.Remove(filepath.Dir(targetPath)))
 			},
-			expectCode:      codes.Internal,
+			expectCode:      codes.NotFound,
 			expectMsgPrefix: "unable to create target path",
 		},
 		{
@@ -307,10 +389,422 @@ func TestNodePublishVolume(t *testing.T) {
 			requireGRPCStatusPrefix(t, err, tt.expectCode, tt.expectMsgPrefix)
 			if err == nil {
 				assert.Equal(t, &csi.NodePublishVolumeResponse{}, resp)
-				assertMounted(t, targetPath, nsmSocketDir)
+				assertMounted(t, client.mounter, targetPath, nsmSocketDir)
+			} else {
+				assert.Nil(t, resp)
+				assertNotMounted(t, client.mounter, targetPath)
+			}
+		})
+	}
+}
+
+func TestNodePublishVolumeRepeated(t *testing.T) {
+	for _, tt := range []struct {
+		desc       string
+		persistent bool
+		otherMount bool
+	}{
+		{
+			desc: "ephemeral volume",
+		},
+		{
+			desc:       "persistent volume",
+			persistent: true,
+		},
+		{
+			desc:       "other mount on the target path",
+			otherMount: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			client, nsmSocketDir := startDriver(t, func(config *Config) {
+				config.PersistentVolumes = true
+			})
+
+			base := t.TempDir()
+			targetPath := filepath.Join(base, "target-path")
+			req := &csi.NodePublishVolumeRequest{
+				VolumeId:   "volumeID",
+				TargetPath: targetPath,
+				Readonly:   true,
+				VolumeCapability: &csi.VolumeCapability{
+					AccessType: &csi.VolumeCapability_Mount{},
+					AccessMode: &csi.VolumeCapability_AccessMode{},
+				},
+				VolumeContext: map[string]string{
+					"csi.storage.k8s.io/ephemeral": "true",
+				},
+			}
+			if tt.persistent {
+				req.StagingTargetPath = filepath.Join(base, "staging-path")
+				req.VolumeContext = nil
+				require.NoError(t, os.Mkdir(req.StagingTargetPath, 0o750))
+				require.NoError(t, client.mounter.Mount(nsmSocketDir, req.StagingTargetPath))
+			}
+			otherSource := t.TempDir()
+			if tt.otherMount {
+				require.NoError(t, os.Mkdir(targetPath, 0o750))
+				require.NoError(t, client.mounter.Mount(otherSource, targetPath))
+			}
+
+			for i := 0; i < 2; i++ {
+				_, err := client.NodePublishVolume(context.Background(), req)
+				require.NoError(t, err)
+			}
+
+			expected := []mount.MountPoint{{Root: nsmSocketDir, Target: targetPath}}
+			if tt.otherMount {
+				expected = []mount.MountPoint{{Root: otherSource, Target: targetPath}, {Root: nsmSocketDir, Target: targetPath}}
+			}
+			var mountPoints []mount.MountPoint
+			list, err := client.mounter.List()
+			require.NoError(t, err)
+			for _, mountPoint := range list {
+				if mountPoint.Target == targetPath {
+					mountPoints = append(mountPoints, mountPoint)
+				}
+			}
+			require.Equal(t, expected, mountPoints)
+		})
+	}
+}
+
+func TestNodePublishVolumeTargetDir(t *testing.T) {
+	client, _ := startDriver(t)
+	targetPath := filepath.Join(t.TempDir(), "target-path")
+
+	_, err := client.NodePublishVolume(context.Background(), &csi.NodePublishVolumeRequest{
+		VolumeId:   "volumeID",
+		TargetPath: targetPath,
+		Readonly:   true,
+		VolumeCapability: &csi.VolumeCapability{
+			AccessType: &csi.VolumeCapability_Mount{},
+			AccessMode: &csi.VolumeCapability_AccessMode{},
+		},
+		VolumeContext: map[string]string{
+			"csi.storage.k8s.io/ephemeral": "true",
+			TargetDirModeAttribute:         "0701",
+			TargetDirUIDAttribute:          strconv.Itoa(os.Getuid()),
+			TargetDirGIDAttribute:          strconv.Itoa(os.Getgid()),
+		},
+	})
+	require.NoError(t, err)
+
+	info, err := os.Stat(targetPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o701), info.Mode().Perm())
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	require.True(t, ok)
+	require.Equal(t, uint32(os.Getuid()), stat.Uid)
+	require.Equal(t, uint32(os.Getgid()), stat.Gid)
+}
+
+func TestBindMount(t *testing.T) {
+	if !nstest.Run(t) {
+		return
+	}
+
+	mounter := mount.New()
+	client, nsmSocketDir := startDriver(t, func(config *Config) {
+		config.PersistentVolumes = true
+		config.Mounter = mounter
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(nsmSocketDir, "nsm.io.sock"), nil, 0o600))
+	volumeCapability := &csi.VolumeCapability{
+		AccessType: &csi.VolumeCapability_Mount{},
+		AccessMode: &csi.VolumeCapability_AccessMode{},
+	}
+	requireMountPoint := func(path string, expected bool) {
+		ok, err := mounter.IsMountPoint(path)
+		require.NoError(t, err)
+		require.Equal(t, expected, ok, "unexpected mount point state of %s", path)
+	}
+
+	t.Run("ephemeral volume", func(t *testing.T) {
+		targetPath := filepath.Join(t.TempDir(), "target-path")
+		_, err := client.NodePublishVolume(context.Background(), &csi.NodePublishVolumeRequest{
+			VolumeId:         "volumeID",
+			TargetPath:       targetPath,
+			Readonly:         true,
+			VolumeCapability: volumeCapability,
+			VolumeContext: map[string]string{
+				"csi.storage.k8s.io/ephemeral": "true",
+			},
+		})
+		require.NoError(t, err)
+		requireMountPoint(targetPath, true)
+		require.FileExists(t, filepath.Join(targetPath, "nsm.io.sock"))
+
+		_, err = client.NodeUnpublishVolume(context.Background(), &csi.NodeUnpublishVolumeRequest{
+			VolumeId:   "volumeID",
+			TargetPath: targetPath,
+		})
+		require.NoError(t, err)
+		requireMountPoint(targetPath, false)
+		require.NoDirExists(t, targetPath)
+	})
+
+	t.Run("persistent volume", func(t *testing.T) {
+		stagingPath := filepath.Join(t.TempDir(), "staging-path")
+		targetPath := filepath.Join(t.TempDir(), "target-path")
+		_, err := client.NodeStageVolume(context.Background(), &csi.NodeStageVolumeRequest{
+			VolumeId:          "volumeID",
+			StagingTargetPath: stagingPath,
+			VolumeCapability:  volumeCapability,
+		})
+		require.NoError(t, err)
+		requireMountPoint(stagingPath, true)
+
+		_, err = client.NodePublishVolume(context.Background(), &csi.NodePublishVolumeRequest{
+			VolumeId:          "volumeID",
+			StagingTargetPath: stagingPath,
+			TargetPath:        targetPath,
+			Readonly:          true,
+			VolumeCapability:  volumeCapability,
+		})
+		require.NoError(t, err)
+		requireMountPoint(targetPath, true)
+		require.FileExists(t, filepath.Join(targetPath, "nsm.io.sock"))
+
+		_, err = client.NodeUnpublishVolume(context.Background(), &csi.NodeUnpublishVolumeRequest{
+			VolumeId:   "volumeID",
+			TargetPath: targetPath,
+		})
+		require.NoError(t, err)
+		requireMountPoint(targetPath, false)
+
+		_, err = client.NodeUnstageVolume(context.Background(), &csi.NodeUnstageVolumeRequest{
+			VolumeId:          "volumeID",
+			StagingTargetPath: stagingPath,
+		})
+		require.NoError(t, err)
+		requireMountPoint(stagingPath, false)
+		require.NoDirExists(t, stagingPath)
+	})
+}
+
+func TestNodePublishPersistentVolume(t *testing.T) {
+	for _, tt := range []struct {
+		desc             string
+		mutateReq        func(req *csi.NodePublishVolumeRequest)
+		mungeStagingPath func(t *testing.T, mounter *mount.Fake, stagingPath string)
+		expectCode       codes.Code
+		expectMsgPrefix  string
+	}{
+		{
+			desc: "missing staging target path",
+			mutateReq: func(req *csi.NodePublishVolumeRequest) {
+				req.StagingTargetPath = ""
+			},
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "request missing required staging target path",
+		},
+		{
+			desc: "volume is not staged",
+			mungeStagingPath: func(t *testing.T, mounter *mount.Fake, stagingPath string) {
+				require.NoError(t, mounter.Unmount(stagingPath))
+			},
+			expectCode:      codes.FailedPrecondition,
+			expectMsgPrefix: "volume is not staged",
+		},
+		{
+			desc:       "success",
+			expectCode: codes.OK,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			client, nsmSocketDir := startDriver(t, func(config *Config) {
+				config.PersistentVolumes = true
+			})
+
+			base := t.TempDir()
+			stagingPath := filepath.Join(base, "staging-path")
+			targetPath := filepath.Join(base, "target-path")
+
+			// Stage the volume
+			require.NoError(t, os.Mkdir(stagingPath, 0o750))
+			require.NoError(t, client.mounter.Mount(nsmSocketDir, stagingPath))
+
+			if tt.mungeStagingPath != nil {
+				tt.mungeStagingPath(t, client.mounter, stagingPath)
+			}
+
+			req := &csi.NodePublishVolumeRequest{
+				VolumeId:          "volumeID",
+				StagingTargetPath: stagingPath,
+				TargetPath:        targetPath,
+				Readonly:          true,
+				VolumeCapability: &csi.VolumeCapability{
+					AccessType: &csi.VolumeCapability_Mount{},
+					AccessMode: &csi.VolumeCapability_AccessMode{},
+				},
+			}
+			if tt.mutateReq != nil {
+				tt.mutateReq(req)
+			}
+
+			resp, err := client.NodePublishVolume(context.Background(), req)
+			requireGRPCStatusPrefix(t, err, tt.expectCode, tt.expectMsgPrefix)
+			if err == nil {
+				assert.Equal(t, &csi.NodePublishVolumeResponse{}, resp)
+				assertMounted(t, client.mounter, targetPath, nsmSocketDir)
+			} else {
+				assert.Nil(t, resp)
+				assertNotMounted(t, client.mounter, targetPath)
+			}
+		})
+	}
+}
+
+func TestNodeStageVolume(t *testing.T) {
+	for _, tt := range []struct {
+		desc             string
+		disabled         bool
+		mutateReq        func(req *csi.NodeStageVolumeRequest)
+		mungeStagingPath func(t *testing.T, mounter *mount.Fake, stagingPath string)
+		expectCode       codes.Code
+		expectMsgPrefix  string
+	}{
+		{
+			desc:            "persistent volumes are not enabled",
+			disabled:        true,
+			expectCode:      codes.Unimplemented,
+			expectMsgPrefix: "persistent volumes are not enabled",
+		},
+		{
+			desc: "missing volume id",
+			mutateReq: func(req *csi.NodeStageVolumeRequest) {
+				req.VolumeId = ""
+			},
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "request missing required volume id",
+		},
+		{
+			desc: "missing staging target path",
+			mutateReq: func(req *csi.NodeStageVolumeRequest) {
+				req.StagingTargetPath = ""
+			},
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "request missing required staging target path",
+		},
+		{
+			desc: "missing volume capability",
+			mutateReq: func(req *csi.NodeStageVolumeRequest) {
+				req.VolumeCapability = nil
+			},
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "request missing required volume capability",
+		},
+		{
+			desc: "mount failure",
+			mungeStagingPath: func(t *testing.T, _ *mount.Fake, stagingPath string) {
+				require.NoError(t, os.WriteFile(stagingPath, nil, 0o600))
+			},
+			expectCode:      codes.Internal,
+			expectMsgPrefix: "unable to mount",
+		},
+		{
+			desc: "already staged",
+			mungeStagingPath: func(t *testing.T, mounter *mount.Fake, stagingPath string) {
+				require.NoError(t, os.Mkdir(stagingPath, 0o750))
+				require.NoError(t, mounter.Mount(t.TempDir(), stagingPath))
+			},
+			expectCode: codes.OK,
+		},
+		{
+			desc:       "success",
+			expectCode: codes.OK,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			client, nsmSocketDir := startDriver(t, func(config *Config) {
+				config.PersistentVolumes = !tt.disabled
+			})
+			stagingPath := filepath.Join(t.TempDir(), "staging-path")
+
+			if tt.mungeStagingPath != nil {
+				tt.mungeStagingPath(t, client.mounter, stagingPath)
+			}
+
+			req := &csi.NodeStageVolumeRequest{
+				VolumeId:          "volumeID",
+				StagingTargetPath: stagingPath,
+				VolumeCapability: &csi.VolumeCapability{
+					AccessType: &csi.VolumeCapability_Mount{},
+					AccessMode: &csi.VolumeCapability_AccessMode{},
+				},
+			}
+			if tt.mutateReq != nil {
+				tt.mutateReq(req)
+			}
+
+			resp, err := client.NodeStageVolume(context.Background(), req)
+			requireGRPCStatusPrefix(t, err, tt.expectCode, tt.expectMsgPrefix)
+			if err != nil {
+				assert.Nil(t, resp)
+				return
+			}
+			assert.Equal(t, &csi.NodeStageVolumeResponse{}, resp)
+			if tt.mungeStagingPath == nil {
+				assertMounted(t, client.mounter, stagingPath, nsmSocketDir)
+			}
+		})
+	}
+}
+
+func TestNodeUnstageVolume(t *testing.T) {
+	client, nsmSocketDir := startDriver(t, func(config *Config) {
+		config.PersistentVolumes = true
+	})
+
+	for _, tt := range []struct {
+		desc             string
+		mungeStagingPath func(t *testing.T, mounter *mount.Fake, stagingPath string)
+		expectCode       codes.Code
+		expectMsgPrefix  string
+	}{
+		{
+			desc: "already unstaged",
+			mungeStagingPath: func(t *testing.T, mounter *mount.Fake, stagingPath string) {
+				require.NoError(t, mounter.Unmount(stagingPath))
+				require.NoError(t, os.RemoveAll(stagingPath))
+			},
+			expectCode: codes.OK,
+		},
+		{
+			desc: "not mounted",
+			mungeStagingPath: func(t *testing.T, mounter *mount.Fake, stagingPath string) {
+				require.NoError(t, mounter.Unmount(stagingPath))
+			},
+			expectCode: codes.OK,
+		},
+		{
+			desc:       "success",
+			expectCode: codes.OK,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			stagingPath := filepath.Join(t.TempDir(), "staging-path")
+
+			// Stage the volume
+			require.NoError(t, os.Mkdir(stagingPath, 0o750))
+			require.NoError(t, client.mounter.Mount(nsmSocketDir, stagingPath))
+
+			if tt.mungeStagingPath != nil {
+				tt.mungeStagingPath(t, client.mounter, stagingPath)
+			}
+
+			resp, err := client.NodeUnstageVolume(context.Background(), &csi.NodeUnstageVolumeRequest{
+				VolumeId:          "volumeID",
+				StagingTargetPath: stagingPath,
+			})
+			requireGRPCStatusPrefix(t, err, tt.expectCode, tt.expectMsgPrefix)
+			if err == nil {
+				assert.Equal(t, &csi.NodeUnstageVolumeResponse{}, resp)
+				assert.NoDirExists(t, stagingPath)
+				assertNotMounted(t, client.mounter, stagingPath)
 			} else {
 				assert.Nil(t, resp)
-				assertNotMounted(t, targetPath)
 			}
 		})
 	}
@@ -322,7 +816,7 @@ func TestNodeUnpublishVolume(t *testing.T) {
 	for _, tt := range []struct {
 		desc            string
 		mutateReq       func(req *csi.NodeUnpublishVolumeRequest)
-		mungeTargetPath func(t *testing.T, targetPath string)
+		mungeTargetPath func(t *testing.T, mounter *mount.Fake, targetPath string)
 		expectCode      codes.Code
 		expectMsgPrefix string
 	}{
@@ -343,17 +837,23 @@ func TestNodeUnpublishVolume(t *testing.T) {
 			expectMsgPrefix: "request missing required target path",
 		},
 		{
-			desc: "unmount failure",
-			mungeTargetPath: func(t *testing.T, targetPath string) {
-				// Removing the meta file to simulate that it wasn't mounted
-				require.NoError(t, os.Remove(metaPath(targetPath)))
+			desc: "not mounted",
+			mungeTargetPath: func(t *testing.T, mounter *mount.Fake, targetPath string) {
+				require.NoError(t, mounter.Unmount(targetPath))
 			},
-			expectCode:      codes.Internal,
-			expectMsgPrefix: "unable to unmount",
+			expectCode: codes.OK,
+		},
+		{
+			desc: "already unpublished",
+			mungeTargetPath: func(t *testing.T, mounter *mount.Fake, targetPath string) {
+				require.NoError(t, mounter.Unmount(targetPath))
+				require.NoError(t, os.Remove(targetPath))
+			},
+			expectCode: codes.OK,
 		},
 		{
 			desc: "unable to remove target path after unmounting",
-			mungeTargetPath: func(t *testing.T, targetPath string) {
+			mungeTargetPath: func(t *testing.T, _ *mount.Fake, targetPath string) {
 				// Prevent the directory from being removed by writing
 				// a file into it.
 				require.NoError(t, os.WriteFile(filepath.Join(targetPath, "prevent-directory-removal"), nil, 0o600))
@@ -370,12 +870,12 @@ func TestNodeUnpublishVolume(t *testing.T) {
 			targetPathBase := t.TempDir()
 			targetPath := filepath.Join(targetPathBase, "target-path")
 
-			// Write out the meta file to simulate a successful mount
+			// Mount the target path to simulate a published volume
 			require.NoError(t, os.Mkdir(targetPath, 0o750))
-			require.NoError(t, writeMeta(targetPath, nsmSocketDir))
+			require.NoError(t, client.mounter.Mount(nsmSocketDir, targetPath))
 
 			if tt.mungeTargetPath != nil {
-				tt.mungeTargetPath(t, targetPath)
+				tt.mungeTargetPath(t, client.mounter, targetPath)
 			}
 
 			req := &csi.NodeUnpublishVolumeRequest{
@@ -390,7 +890,8 @@ func TestNodeUnpublishVolume(t *testing.T) {
 			dumpIt(t, "AFTER", targetPathBase)
 			requireGRPCStatusPrefix(t, err, tt.expectCode, tt.expectMsgPrefix)
 			if err == nil {
-				assertNotMounted(t, targetPath)
+				assertNotMounted(t, client.mounter, targetPath)
+				assert.NoDirExists(t, targetPath)
 				assert.Equal(t, &csi.NodeUnpublishVolumeResponse{}, resp)
 			} else {
 				assert.Nil(t, resp)
@@ -399,6 +900,91 @@ func TestNodeUnpublishVolume(t *testing.T) {
 	}
 }
 
+func TestNodeGetVolumeStats(t *testing.T) {
+	client, nsmSocketDir := startDriver(t)
+
+	for _, tt := range []struct {
+		desc            string
+		mutateReq       func(req *csi.NodeGetVolumeStatsRequest)
+		mungeVolumePath func(t *testing.T, mounter *mount.Fake, volumePath string)
+		expectCode      codes.Code
+		expectMsgPrefix string
+	}{
+		{
+			desc: "missing volume id",
+			mutateReq: func(req *csi.NodeGetVolumeStatsRequest) {
+				req.VolumeId = ""
+			},
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "request missing required volume id",
+		},
+		{
+			desc: "missing volume path",
+			mutateReq: func(req *csi.NodeGetVolumeStatsRequest) {
+				req.VolumePath = ""
+			},
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "request missing required volume path",
+		},
+		{
+			desc: "volume path does not exist",
+			mungeVolumePath: func(t *testing.T, mounter *mount.Fake, volumePath string) {
+				require.NoError(t, mounter.Unmount(volumePath))
+				require.NoError(t, os.Remove(volumePath))
+			},
+			expectCode:      codes.NotFound,
+			expectMsgPrefix: "volume path",
+		},
+		{
+			desc: "volume not published",
+			mungeVolumePath: func(t *testing.T, mounter *mount.Fake, volumePath string) {
+				require.NoError(t, mounter.Unmount(volumePath))
+			},
+			expectCode:      codes.NotFound,
+			expectMsgPrefix: `volume "volumeID" is not published`,
+		},
+		{
+			desc:       "success",
+			expectCode: codes.OK,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			volumePath := filepath.Join(t.TempDir(), "target-path")
+
+			// Mount the volume path to simulate a published volume
+			require.NoError(t, os.Mkdir(volumePath, 0o750))
+			require.NoError(t, client.mounter.Mount(nsmSocketDir, volumePath))
+
+			if tt.mungeVolumePath != nil {
+				tt.mungeVolumePath(t, client.mounter, volumePath)
+			}
+
+			req := &csi.NodeGetVolumeStatsRequest{
+				VolumeId:   "volumeID",
+				VolumePath: volumePath,
+			}
+			if tt.mutateReq != nil {
+				tt.mutateReq(req)
+			}
+			resp, err := client.NodeGetVolumeStats(context.Background(), req)
+			requireGRPCStatusPrefix(t, err, tt.expectCode, tt.expectMsgPrefix)
+			if err != nil {
+				assert.Nil(t, resp)
+				return
+			}
+			assert.False(t, resp.GetVolumeCondition().GetAbnormal())
+			require.Len(t, resp.GetUsage(), 2)
+			for i, unit := range []csi.VolumeUsage_Unit{csi.VolumeUsage_BYTES, csi.VolumeUsage_INODES} {
+				usage := resp.GetUsage()[i]
+				assert.Equal(t, unit, usage.GetUnit())
+				assert.Positive(t, usage.GetTotal())
+				// The blocks reserved for root are neither available nor used
+				assert.LessOrEqual(t, usage.GetAvailable()+usage.GetUsed(), usage.GetTotal())
+			}
+		})
+	}
+}
+
 func requireGRPCStatusPrefix(tb testing.TB, err error, code codes.Code, msgPrefix string, msgAndArgs ...interface{}) {
 	st := status.Convert(err)
 	if code != st.Code() || !strings.HasPrefix(st.Message(), msgPrefix) {
@@ -420,20 +1006,28 @@ func requireProtoEqual(tb testing.TB, expected, actual interface{}, msgAndArgs .
 type client struct {
 	csi.IdentityClient
 	csi.NodeClient
+	csi.ControllerClient
+
+	driver  *Driver
+	mounter *mount.Fake
 }
 
-func startDriver(t *testing.T) (c client, nsmSocketDir string) {
+func startDriver(t *testing.T, mutateConfig ...func(config *Config)) (c client, nsmSocketDir string) {
 	nsmSocketDir = t.TempDir()
+	mounter := mount.NewFake()
 
-	d, err := New(&Config{
-		Log:           log.FromContext(context.Background()),
-		NodeID:        testNodeID,
-		PluginName:    "csi.networkservicemesh.io",
-		Version:       "v1.0.0",
-		NSMSocketDir:  nsmSocketDir,
-		customMount:   bindMountRWTest,
-		customUnmount: unmountTest,
-	})
+	config := &Config{
+		Log:          log.FromContext(context.Background()),
+		NodeID:       testNodeID,
+		PluginName:   "csi.networkservicemesh.io",
+		Version:      "v1.0.0",
+		NSMSocketDir: nsmSocketDir,
+		Mounter:      mounter,
+	}
+	for _, mutate := range mutateConfig {
+		mutate(config)
+	}
+	d, err := New(config)
 	require.NoError(t, err)
 
 	l, err := net.Listen("tcp", "localhost:0")
@@ -444,6 +1038,7 @@ func startDriver(t *testing.T) (c client, nsmSocketDir string) {
 
 	csi.RegisterIdentityServer(s, d)
 	csi.RegisterNodeServer(s, d)
+	csi.RegisterControllerServer(s, d)
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
@@ -475,34 +1070,26 @@ func startDriver(t *testing.T) (c client, nsmSocketDir string) {
 	}
 
 	return client{
-		IdentityClient: csi.NewIdentityClient(conn),
-		NodeClient:     csi.NewNodeClient(conn),
+		IdentityClient:   csi.NewIdentityClient(conn),
+		NodeClient:       csi.NewNodeClient(conn),
+		ControllerClient: csi.NewControllerClient(conn),
+		driver:           d,
+		mounter:          mounter,
 	}, nsmSocketDir
 }
 
-func assertMounted(t *testing.T, targetPath, src string) {
-	meta, err := readMeta(targetPath)
+func assertMounted(t *testing.T, mounter *mount.Fake, targetPath, src string) {
+	mountPoints, err := mounter.List()
 	if assert.NoError(t, err) {
-		assert.Equal(t, src, meta)
+		assert.Contains(t, mountPoints, mount.MountPoint{Root: src, Target: targetPath})
 	}
 }
 
-func assertNotMounted(t *testing.T, targetPath string) {
-	_, err := readMeta(targetPath)
-	assert.Error(t, err, "should not be mounted")
-}
-
-func readMeta(targetPath string) (string, error) {
-	data, err := os.ReadFile(metaPath(targetPath))
-	return string(data), err
-}
-
-func writeMeta(targetPath, meta string) error {
-	return os.WriteFile(metaPath(targetPath), []byte(meta), 0o600)
-}
-
-func metaPath(targetPath string) string {
-	return filepath.Join(targetPath, "meta")
+func assertNotMounted(t *testing.T, mounter *mount.Fake, targetPath string) {
+	ok, err := mounter.IsMountPoint(targetPath)
+	if assert.NoError(t, err) {
+		assert.False(t, ok, "should not be mounted")
+	}
 }
 
 func dumpIt(t *testing.T, when, dir string) {
@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drivertest serves the driver in-process for the integration tests of the projects relying on it. The
+// driver is served by pkg/server on a temporary Unix socket and mounts with an in-memory fake, so no root is needed.
+package drivertest
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/driver"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/mount"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/server"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// NodeID is the node ID of the driver
+	NodeID = "drivertest-node"
+	// PluginName is the plugin name of the driver
+	PluginName = "csi.networkservicemesh.io"
+	// Version is the version of the driver
+	Version = "v0.0.0-drivertest"
+)
+
+// Driver is a driver served in-process, it is stopped at the end of the test
+type Driver struct {
+	csi.IdentityClient
+	csi.NodeClient
+
+	// Mounter records the mounts of the driver
+	Mounter *mount.Fake
+	// NSMSocketDir is the temporary NSM socket directory published by the driver
+	NSMSocketDir string
+	// SocketPath is the Unix socket the driver is served on
+	SocketPath string
+}
+
+// Start serves the driver for the test. The config can be changed by mutateConfig before the driver is created,
+// e.g. to enable persistent volumes.
+func Start(t testing.TB, mutateConfig ...func(config *driver.Config)) *Driver {
+	t.Helper()
+
+	// Unix socket paths are limited to 108 characters, so the socket isn't created in t.TempDir
+	socketDir, err := os.MkdirTemp("", "csi")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(socketDir) })
+
+	d := &Driver{
+		Mounter:      mount.NewFake(),
+		NSMSocketDir: t.TempDir(),
+		SocketPath:   filepath.Join(socketDir, "csi.sock"),
+	}
+
+	config := &driver.Config{
+		Log:          log.L(),
+		NodeID:       NodeID,
+		PluginName:   PluginName,
+		Version:      Version,
+		NSMSocketDir: d.NSMSocketDir,
+		Mounter:      d.Mounter,
+	}
+	for _, mutate := range mutateConfig {
+		mutate(config)
+	}
+	csiDriver, err := driver.New(config)
+	require.NoError(t, err)
+
+	listener, err := net.Listen("unix", d.SocketPath)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(ctx, listener, server.WithLog(config.Log), server.WithDriver(csiDriver))
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-errCh)
+	})
+
+	conn, err := grpc.NewClient("unix://"+d.SocketPath, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	d.IdentityClient = csi.NewIdentityClient(conn)
+	d.NodeClient = csi.NewNodeClient(conn)
+	return d
+}
+
+// PublishRequest returns a valid request publishing an ephemeral inline volume to targetPath
+func PublishRequest(volumeID, targetPath string) *csi.NodePublishVolumeRequest {
+	return &csi.NodePublishVolumeRequest{
+		VolumeId:   volumeID,
+		TargetPath: targetPath,
+		Readonly:   true,
+		VolumeCapability: &csi.VolumeCapability{
+			AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{}},
+			AccessMode: &csi.VolumeCapability_AccessMode{
+				Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER,
+			},
+		},
+		VolumeContext: map[string]string{
+			"csi.storage.k8s.io/ephemeral": "true",
+		},
+	}
+}
+
+// RequirePublished checks that the NSM socket directory is mounted to the existing target path, directly for an
+// ephemeral volume or through the staging path for a persistent one
+func (d *Driver) RequirePublished(t testing.TB, targetPath string) {
+	t.Helper()
+	d.RequireMounted(t, d.NSMSocketDir, targetPath)
+}
+
+// RequireMounted checks that source is mounted to the existing target path. Like a bind mount, the mount of a
+// mounted source has the root of its top mount.
+func (d *Driver) RequireMounted(t testing.TB, source, targetPath string) {
+	t.Helper()
+	require.DirExists(t, targetPath)
+	mountPoints, err := d.Mounter.List()
+	require.NoError(t, err)
+	require.Contains(t, mountPoints, mount.MountPoint{Root: source, Target: targetPath}, "%s is not mounted to %s", source, targetPath)
+}
+
+// RequireUnpublished checks that nothing is mounted to the target path and that it is removed
+func (d *Driver) RequireUnpublished(t testing.TB, targetPath string) {
+	t.Helper()
+	ok, err := d.Mounter.IsMountPoint(targetPath)
+	require.NoError(t, err)
+	require.False(t, ok, "%s is still mounted", targetPath)
+	require.NoDirExists(t, targetPath)
+}
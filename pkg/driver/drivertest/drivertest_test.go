@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drivertest_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/driver"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/driver/drivertest"
+)
+
+func TestStart(t *testing.T) {
+	d := drivertest.Start(t)
+
+	info, err := d.GetPluginInfo(context.Background(), &csi.GetPluginInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, drivertest.PluginName, info.Name)
+	require.Equal(t, drivertest.Version, info.VendorVersion)
+
+	nodeInfo, err := d.NodeGetInfo(context.Background(), &csi.NodeGetInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, drivertest.NodeID, nodeInfo.NodeId)
+}
+
+func TestPublishUnpublish(t *testing.T) {
+	d := drivertest.Start(t)
+	targetPath := filepath.Join(t.TempDir(), "target-path")
+
+	_, err := d.NodePublishVolume(context.Background(), drivertest.PublishRequest("volumeID", targetPath))
+	require.NoError(t, err)
+	d.RequirePublished(t, targetPath)
+
+	_, err = d.NodeUnpublishVolume(context.Background(), &csi.NodeUnpublishVolumeRequest{
+		VolumeId:   "volumeID",
+		TargetPath: targetPath,
+	})
+	require.NoError(t, err)
+	d.RequireUnpublished(t, targetPath)
+}
+
+func TestStartWithConfig(t *testing.T) {
+	d := drivertest.Start(t, func(config *driver.Config) {
+		config.PersistentVolumes = true
+	})
+	base := t.TempDir()
+	stagingPath := filepath.Join(base, "staging-path")
+	targetPath := filepath.Join(base, "target-path")
+
+	_, err := d.NodeStageVolume(context.Background(), &csi.NodeStageVolumeRequest{
+		VolumeId:          "volumeID",
+		StagingTargetPath: stagingPath,
+		VolumeCapability:  drivertest.PublishRequest("volumeID", targetPath).VolumeCapability,
+	})
+	require.NoError(t, err)
+	d.RequireMounted(t, d.NSMSocketDir, stagingPath)
+
+	req := drivertest.PublishRequest("volumeID", targetPath)
+	req.StagingTargetPath = stagingPath
+	delete(req.VolumeContext, "csi.storage.k8s.io/ephemeral")
+	_, err = d.NodePublishVolume(context.Background(), req)
+	require.NoError(t, err)
+	d.RequirePublished(t, targetPath)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drivertest_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/kubernetes-csi/csi-test/v5/pkg/sanity"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/driver/drivertest"
+)
+
+// inlineVolumeController stands in for the controller service the driver runs without. The sanity node specs
+// provision their volumes with CreateVolume, so it returns the volumes the kubelet publishes for CSI ephemeral
+// inline volumes. Its identity service is the one of the driver.
+type inlineVolumeController struct {
+	csi.UnimplementedIdentityServer
+	csi.UnimplementedControllerServer
+
+	identity csi.IdentityClient
+}
+
+func (c *inlineVolumeController) GetPluginInfo(ctx context.Context, req *csi.GetPluginInfoRequest) (*csi.GetPluginInfoResponse, error) {
+	return c.identity.GetPluginInfo(ctx, req)
+}
+
+func (c *inlineVolumeController) GetPluginCapabilities(ctx context.Context, req *csi.GetPluginCapabilitiesRequest) (*csi.GetPluginCapabilitiesResponse, error) {
+	return c.identity.GetPluginCapabilities(ctx, req)
+}
+
+func (c *inlineVolumeController) Probe(ctx context.Context, req *csi.ProbeRequest) (*csi.ProbeResponse, error) {
+	return c.identity.Probe(ctx, req)
+}
+
+func (c *inlineVolumeController) CreateVolume(_ context.Context, req *csi.CreateVolumeRequest) (*csi.CreateVolumeResponse, error) {
+	return &csi.CreateVolumeResponse{
+		Volume: &csi.Volume{
+			VolumeId: req.Name,
+			VolumeContext: map[string]string{
+				"csi.storage.k8s.io/ephemeral": "true",
+			},
+		},
+	}, nil
+}
+
+func (c *inlineVolumeController) DeleteVolume(context.Context, *csi.DeleteVolumeRequest) (*csi.DeleteVolumeResponse, error) {
+	return &csi.DeleteVolumeResponse{}, nil
+}
+
+func (c *inlineVolumeController) ControllerGetCapabilities(context.Context, *csi.ControllerGetCapabilitiesRequest) (*csi.ControllerGetCapabilitiesResponse, error) {
+	return &csi.ControllerGetCapabilitiesResponse{
+		Capabilities: []*csi.ControllerServiceCapability{
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{
+						Type: csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// serveInlineVolumeController serves inlineVolumeController next to the driver socket and returns its address
+func serveInlineVolumeController(t *testing.T, d *drivertest.Driver) string {
+	socketPath := filepath.Join(filepath.Dir(d.SocketPath), "controller.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	controller := &inlineVolumeController{identity: d.IdentityClient}
+	server := grpc.NewServer()
+	csi.RegisterIdentityServer(server, controller)
+	csi.RegisterControllerServer(server, controller)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	return "unix://" + socketPath
+}
+
+// readOnlyPublish publishes the volumes read-only as the kubelet does for the volumes of the driver, the sanity
+// suite always publishes them read-write
+func readOnlyPublish(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if publish, ok := req.(*csi.NodePublishVolumeRequest); ok {
+		publish.Readonly = true
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// TestSanity runs the CSI sanity suite against the node-only capabilities the driver advertises by default
+func TestSanity(t *testing.T) {
+	d := drivertest.Start(t)
+
+	config := sanity.NewTestConfig()
+	config.Address = "unix://" + d.SocketPath
+	config.DialOptions = append(config.DialOptions, grpc.WithUnaryInterceptor(readOnlyPublish))
+	config.ControllerAddress = serveInlineVolumeController(t, d)
+	config.TargetPath = filepath.Join(t.TempDir(), "target")
+	config.StagingPath = filepath.Join(t.TempDir(), "staging")
+
+	sc := sanity.GinkgoTest(&config)
+	defer sc.Finalize()
+
+	suiteConfig, reporterConfig := ginkgo.GinkgoConfiguration()
+	suiteConfig.SkipStrings = append(suiteConfig.SkipStrings, `\[Controller Server\]`)
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "CSI Sanity Suite", suiteConfig, reporterConfig)
+}
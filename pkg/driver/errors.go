@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/errorinfo"
+)
+
+// Metadata keys of the ErrorInfo details
+const (
+	errorInfoPath      = "path"
+	errorInfoTransient = "transient"
+)
+
+// nodeError is a Node service error with the reason and metadata of its ErrorInfo detail, added by withErrorInfo
+type nodeError struct {
+	status   *status.Status
+	reason   string
+	metadata map[string]string
+}
+
+func newNodeError(code codes.Code, reason, path, format string, args ...interface{}) error {
+	return &nodeError{
+		status:   status.Newf(code, format, args...),
+		reason:   reason,
+		metadata: map[string]string{errorInfoPath: path},
+	}
+}
+
+func (e *nodeError) Error() string {
+	return e.status.Err().Error()
+}
+
+// GRPCStatus returns the status of the error without the ErrorInfo detail
+func (e *nodeError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// fsError classifies the failure of a mount or file system operation on path by its errno, it is Internal with the
+// given reason if the errno tells nothing. A status error, e.g. DeadlineExceeded, is returned as is, even wrapped. The
+// message is prefixed with format, if not empty.
+func fsError(err error, reason, path, format string, args ...interface{}) error {
+	var statusErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &statusErr) {
+		return err
+	}
+	message := err.Error()
+	if format != "" {
+		message = fmt.Sprintf(format, args...) + ": " + message
+	}
+
+	code := codes.Internal
+	var errno syscall.Errno
+	if errors.As(err, &errno) {
+		switch errno {
+		case syscall.ENOENT:
+			code, reason = codes.NotFound, errorinfo.PathNotFound
+		case syscall.ENOTDIR, syscall.EINVAL:
+			code, reason = codes.FailedPrecondition, errorinfo.InvalidPath
+		case syscall.EACCES, syscall.EPERM, syscall.EROFS:
+			code, reason = codes.PermissionDenied, errorinfo.PermissionDenied
+		case syscall.ENOSPC, syscall.EDQUOT, syscall.ENOMEM, syscall.EMFILE, syscall.ENFILE:
+			code, reason = codes.ResourceExhausted, errorinfo.OutOfResources
+		case syscall.EBUSY:
+			code, reason = codes.Unavailable, errorinfo.TargetBusy
+		case syscall.ENOTCONN, syscall.ESTALE, syscall.EIO, syscall.EAGAIN, syscall.EINTR, syscall.ETIMEDOUT:
+			code, reason = codes.Unavailable, errorinfo.FilesystemUnavailable
+		}
+	}
+	return newNodeError(code, reason, path, "%s", message)
+}
+
+// withErrorInfo adds the ErrorInfo detail to the status of a Node service error, with the reason of a nodeError or
+// the status code, and whether the error is transient, i.e. the same request is expected to succeed when retried
+func (d *Driver) withErrorInfo(err error) error {
+	if err == nil {
+		return nil
+	}
+	st := status.Convert(err)
+	if st.Code() == codes.OK || len(st.Details()) > 0 {
+		return err
+	}
+
+	info := &errdetails.ErrorInfo{
+		Reason:   codeReason(st.Code()),
+		Domain:   d.pluginName,
+		Metadata: map[string]string{},
+	}
+	var nodeErr *nodeError
+	if errors.As(err, &nodeErr) {
+		info.Reason = nodeErr.reason
+		for key, value := range nodeErr.metadata {
+			info.Metadata[key] = value
+		}
+	}
+	info.Metadata[errorInfoTransient] = strconv.FormatBool(isTransient(st.Code()))
+
+	withInfo, detailsErr := st.WithDetails(info)
+	if detailsErr != nil {
+		return err
+	}
+	return withInfo.Err()
+}
+
+// isTransient checks whether the failure is expected to go away without a change of the request or the node, e.g.
+// a busy target or a pending operation
+func isTransient(code codes.Code) bool {
+	switch code {
+	case codes.Unavailable, codes.Aborted, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Canceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// codeReason converts a status code to an ErrorInfo reason, e.g. InvalidArgument to INVALID_ARGUMENT
+func codeReason(code codes.Code) string {
+	var sb strings.Builder
+	for i, r := range code.String() {
+		if i > 0 && unicode.IsUpper(r) {
+			sb.WriteByte('_')
+		}
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+	return sb.String()
+}
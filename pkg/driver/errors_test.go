@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/errorinfo"
+)
+
+func TestFSError(t *testing.T) {
+	for _, tt := range []struct {
+		err          error
+		expectCode   codes.Code
+		expectReason string
+	}{
+		{err: errors.New("boom"), expectCode: codes.Internal, expectReason: errorinfo.MountFailed},
+		{err: errors.Wrap(syscall.ENOENT, "mkdir"), expectCode: codes.NotFound, expectReason: errorinfo.PathNotFound},
+		{err: &os.PathError{Op: "mkdir", Err: syscall.ENOTDIR}, expectCode: codes.FailedPrecondition, expectReason: errorinfo.InvalidPath},
+		{err: syscall.EACCES, expectCode: codes.PermissionDenied, expectReason: errorinfo.PermissionDenied},
+		{err: syscall.EROFS, expectCode: codes.PermissionDenied, expectReason: errorinfo.PermissionDenied},
+		{err: syscall.ENOSPC, expectCode: codes.ResourceExhausted, expectReason: errorinfo.OutOfResources},
+		{err: syscall.EBUSY, expectCode: codes.Unavailable, expectReason: errorinfo.TargetBusy},
+		{err: syscall.ENOTCONN, expectCode: codes.Unavailable, expectReason: errorinfo.FilesystemUnavailable},
+		{err: syscall.ENOTEMPTY, expectCode: codes.Internal, expectReason: errorinfo.MountFailed},
+		{err: newNodeError(codes.DeadlineExceeded, errorinfo.OperationTimeout, "/path", "timeout"), expectCode: codes.DeadlineExceeded, expectReason: errorinfo.OperationTimeout},
+		{err: errors.Wrap(newNodeError(codes.Aborted, errorinfo.OperationPending, "/path", "pending"), "mkdir"), expectCode: codes.Aborted, expectReason: errorinfo.OperationPending},
+	} {
+		err := fsError(tt.err, errorinfo.MountFailed, "/path", "unable to mount %q", "/path")
+		require.Equal(t, tt.expectCode, status.Code(err), tt.err.Error())
+		var nodeErr *nodeError
+		require.True(t, errors.As(err, &nodeErr), tt.err.Error())
+		require.Equal(t, tt.expectReason, nodeErr.reason, tt.err.Error())
+	}
+}
+
+func TestCodeReason(t *testing.T) {
+	require.Equal(t, "INVALID_ARGUMENT", codeReason(codes.InvalidArgument))
+	require.Equal(t, "UNIMPLEMENTED", codeReason(codes.Unimplemented))
+	require.Equal(t, "FAILED_PRECONDITION", codeReason(codes.FailedPrecondition))
+}
+
+func TestNodeErrorInfo(t *testing.T) {
+	for _, tt := range []struct {
+		desc             string
+		mutateReq        func(t *testing.T, req *csi.NodePublishVolumeRequest)
+		nsmSocketDirGone bool
+		expectCode       codes.Code
+		expectReason     string
+		expectTransient  string
+	}{
+		{
+			desc: "invalid argument",
+			mutateReq: func(_ *testing.T, req *csi.NodePublishVolumeRequest) {
+				req.Readonly = false
+			},
+			expectCode:      codes.InvalidArgument,
+			expectReason:    "INVALID_ARGUMENT",
+			expectTransient: "false",
+		},
+		{
+			desc:             "NSM socket directory missing",
+			nsmSocketDirGone: true,
+			expectCode:       codes.Unavailable,
+			expectReason:     errorinfo.NSMSocketDirMissing,
+			expectTransient:  "true",
+		},
+		{
+			desc: "target path parent missing",
+			mutateReq: func(t *testing.T, req *csi.NodePublishVolumeRequest) {
+				req.TargetPath = filepath.Join(t.TempDir(), "missing", "target-path")
+			},
+			expectCode:      codes.NotFound,
+			expectReason:    errorinfo.PathNotFound,
+			expectTransient: "false",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			client, nsmSocketDir := startDriver(t)
+			if tt.nsmSocketDirGone {
+				require.NoError(t, os.Remove(nsmSocketDir))
+			}
+			req := &csi.NodePublishVolumeRequest{
+				VolumeId:   "volumeID",
+				TargetPath: filepath.Join(t.TempDir(), "target-path"),
+				Readonly:   true,
+				VolumeCapability: &csi.VolumeCapability{
+					AccessType: &csi.VolumeCapability_Mount{},
+					AccessMode: &csi.VolumeCapability_AccessMode{},
+				},
+				VolumeContext: map[string]string{
+					"csi.storage.k8s.io/ephemeral": "true",
+				},
+			}
+			if tt.mutateReq != nil {
+				tt.mutateReq(t, req)
+			}
+
+			_, err := client.NodePublishVolume(context.Background(), req)
+			st := status.Convert(err)
+			require.Equal(t, tt.expectCode, st.Code())
+			require.Len(t, st.Details(), 1)
+			info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			require.True(t, ok)
+			require.Equal(t, tt.expectReason, info.Reason)
+			require.Equal(t, "csi.networkservicemesh.io", info.Domain)
+			require.Equal(t, tt.expectTransient, info.Metadata["transient"])
+		})
+	}
+}
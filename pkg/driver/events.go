@@ -0,0 +1,38 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/events"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// reportPublishFailure reports the publish failure as an event on the pod of the volume. A failure to report it is
+// logged, it doesn't change the RPC error.
+func (d *Driver) reportPublishFailure(logger log.Logger, volumeContext map[string]string, err error) {
+	if d.events == nil {
+		return
+	}
+	pod := &events.Pod{
+		Name:      volumeContext[podNameKey],
+		Namespace: volumeContext[podNamespaceKey],
+		UID:       volumeContext[podUIDKey],
+	}
+	if reportErr := d.events.PublishFailed(pod, err); reportErr != nil {
+		logger.Errorf("Failed to report the publish failure: %v", reportErr)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/events"
+)
+
+func TestPublishFailureEvents(t *testing.T) {
+	k8sClient := fake.NewSimpleClientset()
+	recorder, err := events.New(&events.Config{
+		Client:    k8sClient,
+		Component: "csi.networkservicemesh.io",
+		NodeName:  testNodeID,
+	})
+	require.NoError(t, err)
+	t.Cleanup(recorder.Shutdown)
+
+	client, nsmSocketDir := startDriver(t, func(config *Config) {
+		config.Events = recorder
+	})
+	// NSM is not running on the node
+	require.NoError(t, os.Remove(nsmSocketDir))
+
+	req := &csi.NodePublishVolumeRequest{
+		VolumeId:   "volumeID",
+		TargetPath: filepath.Join(t.TempDir(), "target-path"),
+		Readonly:   true,
+		VolumeCapability: &csi.VolumeCapability{
+			AccessType: &csi.VolumeCapability_Mount{},
+			AccessMode: &csi.VolumeCapability_AccessMode{},
+		},
+		VolumeContext: map[string]string{
+			"csi.storage.k8s.io/ephemeral":     "true",
+			"csi.storage.k8s.io/pod.name":      "nsc",
+			"csi.storage.k8s.io/pod.namespace": "default",
+			"csi.storage.k8s.io/pod.uid":       "pod-uid",
+		},
+	}
+	_, err = client.NodePublishVolume(context.Background(), req)
+	requireGRPCStatusPrefix(t, err, codes.Unavailable, "NSM socket directory")
+
+	// The pod info is optional, there is no event without it
+	delete(req.VolumeContext, "csi.storage.k8s.io/pod.name")
+	_, err = client.NodePublishVolume(context.Background(), req)
+	requireGRPCStatusPrefix(t, err, codes.Unavailable, "NSM socket directory")
+
+	var list *corev1.EventList
+	require.Eventually(t, func() bool {
+		list, err = k8sClient.CoreV1().Events("default").List(context.Background(), metav1.ListOptions{})
+		return err == nil && len(list.Items) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, list.Items, 1)
+	event := list.Items[0]
+	require.Equal(t, events.ReasonNSMUnavailable, event.Reason)
+	require.Contains(t, event.Message, "NSM socket directory is missing")
+	require.Equal(t, "nsc", event.InvolvedObject.Name)
+	require.Equal(t, "pod-uid", string(event.InvolvedObject.UID))
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/logkeys"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/mount"
+)
+
+const (
+	defaultKubeletPodsDir = "/var/lib/kubelet/pods"
+	meterName             = "github.com/networkservicemesh/cmd-csi-driver/pkg/driver"
+	// mirrorPodAnnotation is the annotation of a mirror pod with the local UID of its static pod
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+)
+
+// Actions taken on the orphaned mounts, reported as the action attribute of the collected mounts metric
+const (
+	gcActionUnmounted = "unmounted"
+	gcActionDryRun    = "dry_run"
+	gcActionSkipped   = "skipped"
+	gcActionFailed    = "failed"
+)
+
+// PodUIDsFunc lists the local UIDs of the pods the kubelet runs, which name their pod directories
+type PodUIDsFunc func(ctx context.Context) (map[string]bool, error)
+
+// LocalPodUIDs returns the local UIDs of the pods. A static pod is known by the kubelet under the hash of its
+// manifest, its mirror pod in the API server has another UID and the local one in its annotation.
+func LocalPodUIDs(pods []corev1.Pod) map[string]bool {
+	uids := make(map[string]bool, len(pods))
+	for i := range pods {
+		uids[string(pods[i].UID)] = true
+		if uid := pods[i].Annotations[mirrorPodAnnotation]; uid != "" {
+			uids[uid] = true
+		}
+	}
+	return uids
+}
+
+// garbageCollector finds the volumes the kubelet never unpublished, e.g. after a node reboot during the pod teardown
+// or a kubelet crash, and unmounts and removes them
+type garbageCollector struct {
+	podsDir string
+	podUIDs PodUIDsFunc
+	dryRun  bool
+
+	sweeps    metric.Int64Counter
+	collected metric.Int64Counter
+}
+
+func newGarbageCollector(config *Config) (*garbageCollector, error) {
+	gc := &garbageCollector{
+		podsDir: config.KubeletPodsDir,
+		podUIDs: config.PodUIDs,
+		dryRun:  config.GCDryRun,
+	}
+	if gc.podsDir == "" {
+		gc.podsDir = defaultKubeletPodsDir
+	}
+
+	provider := config.MeterProvider
+	if provider == nil {
+		provider = otel.GetMeterProvider()
+	}
+	meter := provider.Meter(meterName)
+	var err error
+	if gc.sweeps, err = meter.Int64Counter("csi_gc_sweeps_total",
+		metric.WithDescription("Number of the orphaned volume sweeps by result")); err != nil {
+		return nil, errors.Wrap(err, "unable to create the sweeps metric")
+	}
+	if gc.collected, err = meter.Int64Counter("csi_gc_orphaned_volumes_total",
+		metric.WithDescription("Number of the orphaned volumes found by action taken")); err != nil {
+		return nil, errors.Wrap(err, "unable to create the orphaned volumes metric")
+	}
+	return gc, nil
+}
+
+// orphanedVolume is a volume mount of a pod that no longer exists
+type orphanedVolume struct {
+	podUID     string
+	targetPath string
+}
+
+// RunGarbageCollector collects the orphaned volumes every interval until ctx is done
+func (d *Driver) RunGarbageCollector(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.CollectGarbage(ctx); err != nil {
+			d.logger.Errorf("Failed to collect orphaned volumes: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CollectGarbage unmounts and removes the target paths of the volumes published by the driver whose pod directory
+// is gone or whose pod the kubelet no longer runs, only logging them in dry-run mode. It returns the number of the
+// orphaned volumes found.
+func (d *Driver) CollectGarbage(ctx context.Context) (int, error) {
+	orphans, err := d.findOrphanedVolumes(ctx)
+	if err != nil {
+		d.gc.sweeps.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "error")))
+		return 0, err
+	}
+	d.gc.sweeps.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "ok")))
+
+	for _, orphan := range orphans {
+		action := d.collectOrphanedVolume(ctx, orphan)
+		d.gc.collected.Add(ctx, 1, metric.WithAttributes(attribute.String("action", action)))
+	}
+	return len(orphans), nil
+}
+
+// findOrphanedVolumes lists the mounts of the NSM socket directory on the pod volume paths of the kubelet,
+// <pods dir>/<pod UID>/volumes/kubernetes.io~csi/<volume name>/mount, whose pod is gone
+func (d *Driver) findOrphanedVolumes(ctx context.Context) ([]orphanedVolume, error) {
+	mountPoints, err := d.mounter.List()
+	if err != nil {
+		return nil, err
+	}
+	// The pods are listed after the mounts, so a volume published meanwhile has its pod listed
+	var known map[string]bool
+	if d.gc.podUIDs != nil {
+		if known, err = d.gc.podUIDs(ctx); err != nil {
+			return nil, errors.Wrap(err, "unable to list the pods of the node")
+		}
+	}
+
+	root := socketDirRoot(mountPoints, d.nsmSocketDir)
+	var orphans []orphanedVolume
+	seen := make(map[string]bool)
+	for _, mountPoint := range mountPoints {
+		if mountPoint.Root != root || seen[mountPoint.Target] {
+			continue
+		}
+		podUID, ok := podVolumeUID(d.gc.podsDir, mountPoint.Target)
+		if !ok {
+			continue
+		}
+		seen[mountPoint.Target] = true
+
+		// A pod directory is only collected while it exists if the kubelet no longer runs its pod
+		if _, err := os.Stat(filepath.Join(d.gc.podsDir, podUID)); err == nil && (known == nil || known[podUID]) {
+			continue
+		}
+		orphans = append(orphans, orphanedVolume{podUID: podUID, targetPath: mountPoint.Target})
+	}
+	return orphans, nil
+}
+
+// collectOrphanedVolume unmounts and removes the target path of the orphaned volume, it returns the action taken
+func (d *Driver) collectOrphanedVolume(ctx context.Context, orphan orphanedVolume) string {
+	logger := d.logger.WithField(logkeys.PodUID, orphan.podUID).WithField(logkeys.TargetPath, orphan.targetPath)
+	if d.gc.dryRun {
+		logger.Info("Found orphaned volume, not collected in dry-run mode")
+		return gcActionDryRun
+	}
+
+	// A pending operation on the path, e.g. an unpublish by the kubelet, takes care of it
+	keys := []string{pathLockPrefix + orphan.targetPath}
+	if !d.locks.tryAcquire(keys...) {
+		logger.Info("Skipped orphaned volume with a pending operation")
+		return gcActionSkipped
+	}
+	defer d.locks.release(keys...)
+	if err := d.ops.check(orphan.targetPath); err != nil {
+		logger.Infof("Skipped orphaned volume: %v", err)
+		return gcActionSkipped
+	}
+
+	if err := d.unmountAll(ctx, logger, orphan.targetPath); err != nil {
+		logger.Errorf("Failed to unmount orphaned volume: %v", err)
+		return gcActionFailed
+	}
+	if err := os.Remove(orphan.targetPath); err != nil && !os.IsNotExist(err) {
+		logger.Errorf("Failed to remove orphaned volume target path: %v", err)
+		return gcActionFailed
+	}
+	logger.Info("Collected orphaned volume")
+	return gcActionUnmounted
+}
+
+// socketDirRoot returns the root of the NSM socket directory mounts: the root of the socket directory mount, e.g. a
+// hostPath volume of the driver pod, or the directory itself
+func socketDirRoot(mountPoints []mount.MountPoint, nsmSocketDir string) string {
+	root := nsmSocketDir
+	for _, mountPoint := range mountPoints {
+		if mountPoint.Target == nsmSocketDir {
+			root = mountPoint.Root
+		}
+	}
+	return root
+}
+
+// podVolumeUID returns the pod UID of a CSI volume path of the kubelet
+func podVolumeUID(podsDir, targetPath string) (string, bool) {
+	rel, err := filepath.Rel(podsDir, targetPath)
+	if err != nil {
+		return "", false
+	}
+	parts := strings.Split(rel, string(filepath.Separator))
+	if len(parts) != 5 || parts[0] == ".." || parts[1] != "volumes" || parts[2] != "kubernetes.io~csi" || parts[4] != "mount" {
+		return "", false
+	}
+	return parts[0], true
+}
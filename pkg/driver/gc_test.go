@@ -0,0 +1,211 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/mount"
+)
+
+// testPodUIDs lists the pods the kubelet runs in TestCollectGarbage
+func testPodUIDs(context.Context) (map[string]bool, error) {
+	return LocalPodUIDs([]corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{UID: "running-pod"}},
+		{ObjectMeta: metav1.ObjectMeta{UID: "deleted-pod"}},
+		{ObjectMeta: metav1.ObjectMeta{UID: "pending-pod"}},
+		// The mirror pod of a static pod
+		{ObjectMeta: metav1.ObjectMeta{
+			UID:         "mirror-pod",
+			Annotations: map[string]string{mirrorPodAnnotation: "static-pod"},
+		}},
+	}), nil
+}
+
+// gcVolumes are the target paths mounted in the pods directory for TestCollectGarbage
+type gcVolumes struct {
+	running, static, unknown, pending, deleted string
+	// Not volumes of the driver
+	other, otherSource, notVolume string
+}
+
+func mountGCVolumes(t *testing.T, mounter *mount.Fake, podsDir, nsmSocketDir string) *gcVolumes {
+	volumePath := func(podUID string) string {
+		targetPath := filepath.Join(podsDir, podUID, "volumes", "kubernetes.io~csi", "nsm-socket", "mount")
+		require.NoError(t, os.MkdirAll(targetPath, 0o750))
+		return targetPath
+	}
+	v := &gcVolumes{
+		running:     volumePath("running-pod"),
+		static:      volumePath("static-pod"),
+		unknown:     volumePath("unknown-pod"),
+		pending:     volumePath("pending-other-pod"),
+		deleted:     volumePath("deleted-pod"),
+		other:       volumePath("other-driver-pod"),
+		otherSource: t.TempDir(),
+		notVolume:   filepath.Join(podsDir, "unknown-pod", "volumes", "kubernetes.io~csi", "nsm-socket"),
+	}
+	for _, targetPath := range []string{v.running, v.static, v.unknown, v.unknown, v.pending, v.deleted, v.notVolume} {
+		require.NoError(t, mounter.Mount(nsmSocketDir, targetPath))
+	}
+	require.NoError(t, mounter.Mount(v.otherSource, v.other))
+	require.NoError(t, os.RemoveAll(filepath.Join(podsDir, "deleted-pod")))
+	return v
+}
+
+func TestCollectGarbage(t *testing.T) {
+	for _, tt := range []struct {
+		desc            string
+		dryRun          bool
+		withoutKubelet  bool
+		expectFound     int
+		expectCollected []string
+		expectActions   map[string]int64
+	}{
+		{
+			desc:            "collect",
+			expectFound:     3,
+			expectCollected: []string{"deleted-pod", "unknown-pod"},
+			expectActions:   map[string]int64{gcActionUnmounted: 2, gcActionSkipped: 1},
+		},
+		{
+			desc:          "dry run",
+			dryRun:        true,
+			expectFound:   3,
+			expectActions: map[string]int64{gcActionDryRun: 3},
+		},
+		{
+			desc:            "without the kubelet pods",
+			withoutKubelet:  true,
+			expectFound:     1,
+			expectCollected: []string{"deleted-pod"},
+			expectActions:   map[string]int64{gcActionUnmounted: 1},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			podsDir := t.TempDir()
+			reader := sdkmetric.NewManualReader()
+			client, nsmSocketDir := startDriver(t, func(config *Config) {
+				config.KubeletPodsDir = podsDir
+				config.GCDryRun = tt.dryRun
+				config.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+				if tt.withoutKubelet {
+					return
+				}
+				config.PodUIDs = testPodUIDs
+			})
+			mounter := client.mounter
+			volumes := mountGCVolumes(t, mounter, podsDir, nsmSocketDir)
+
+			// An operation is pending on the volume
+			require.True(t, client.driver.locks.tryAcquire(pathLockPrefix+volumes.pending))
+			defer client.driver.locks.release(pathLockPrefix + volumes.pending)
+
+			n, err := client.driver.CollectGarbage(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tt.expectFound, n)
+
+			for podUID, targetPath := range map[string]string{"unknown-pod": volumes.unknown, "deleted-pod": volumes.deleted} {
+				if slices.Contains(tt.expectCollected, podUID) {
+					assertNotMounted(t, mounter, targetPath)
+					require.NoDirExists(t, targetPath)
+				} else {
+					assertMounted(t, mounter, targetPath, nsmSocketDir)
+				}
+			}
+			for _, targetPath := range []string{volumes.running, volumes.static, volumes.pending, volumes.notVolume} {
+				assertMounted(t, mounter, targetPath, nsmSocketDir)
+			}
+			assertMounted(t, mounter, volumes.other, volumes.otherSource)
+
+			var metrics metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &metrics))
+			require.Equal(t, map[string]int64{"ok": 1}, counterValues(t, metrics, "csi_gc_sweeps_total", "result"))
+			require.Equal(t, tt.expectActions, counterValues(t, metrics, "csi_gc_orphaned_volumes_total", "action"))
+		})
+	}
+}
+
+func TestCollectGarbageSocketDirMount(t *testing.T) {
+	podsDir := t.TempDir()
+	client, nsmSocketDir := startDriver(t, func(config *Config) {
+		config.KubeletPodsDir = podsDir
+	})
+	mounter := client.mounter
+
+	// The NSM socket directory is a hostPath volume of the driver pod, the volumes share its root
+	hostDir := t.TempDir()
+	require.NoError(t, mounter.Mount(hostDir, nsmSocketDir))
+	targetPath := filepath.Join(podsDir, "deleted-pod", "volumes", "kubernetes.io~csi", "nsm-socket", "mount")
+	require.NoError(t, os.MkdirAll(targetPath, 0o750))
+	require.NoError(t, mounter.Mount(hostDir, targetPath))
+	require.NoError(t, os.RemoveAll(filepath.Join(podsDir, "deleted-pod")))
+
+	n, err := client.driver.CollectGarbage(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	assertNotMounted(t, mounter, targetPath)
+	assertMounted(t, mounter, nsmSocketDir, hostDir)
+}
+
+func TestPodVolumeUID(t *testing.T) {
+	for _, tt := range []struct {
+		path      string
+		expectUID string
+		expectOK  bool
+	}{
+		{path: "/pods/uid/volumes/kubernetes.io~csi/nsm-socket/mount", expectUID: "uid", expectOK: true},
+		{path: "/pods/uid/volumes/kubernetes.io~csi/nsm-socket"},
+		{path: "/pods/uid/volumes/kubernetes.io~empty-dir/nsm-socket/mount"},
+		{path: "/pods/uid/volume-subpaths/kubernetes.io~csi/nsm-socket/mount"},
+		{path: "/other/uid/volumes/kubernetes.io~csi/nsm-socket/mount"},
+	} {
+		uid, ok := podVolumeUID("/pods", tt.path)
+		require.Equal(t, tt.expectOK, ok, tt.path)
+		require.Equal(t, tt.expectUID, uid, tt.path)
+	}
+}
+
+// counterValues returns the values of the counter by the value of its attribute key
+func counterValues(t *testing.T, metrics metricdata.ResourceMetrics, name, key string) map[string]int64 {
+	values := make(map[string]int64)
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != name {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok, name)
+			for _, point := range sum.DataPoints {
+				value, _ := point.Attributes.Value(attribute.Key(key))
+				values[value.AsString()] = point.Value
+			}
+		}
+	}
+	return values
+}
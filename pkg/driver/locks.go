@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/errorinfo"
+)
+
+// Prefixes of the operation lock keys, so a volume ID can't collide with a path
+const (
+	volumeLockPrefix = "volume:"
+	pathLockPrefix   = "path:"
+)
+
+// operationLocks serializes the operations by key, the volume ID and the target or staging path. The kubelet retries
+// failed operations, so an overlapping call fails with Aborted instead of waiting, as the CSI spec recommends.
+type operationLocks struct {
+	mu    sync.Mutex
+	locks map[string]struct{}
+}
+
+func newOperationLocks() *operationLocks {
+	return &operationLocks{
+		locks: make(map[string]struct{}),
+	}
+}
+
+// tryAcquire locks all the keys if none of them is locked and reports whether it did
+func (l *operationLocks) tryAcquire(keys ...string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		if _, ok := l.locks[key]; ok {
+			return false
+		}
+	}
+	for _, key := range keys {
+		l.locks[key] = struct{}{}
+	}
+	return true
+}
+
+// release unlocks the keys
+func (l *operationLocks) release(keys ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		delete(l.locks, key)
+	}
+}
+
+// lockOperation locks the volume ID and path of an operation, it fails with Aborted if an operation on either is
+// pending. The returned func releases them.
+func (d *Driver) lockOperation(volumeID, path string) (func(), error) {
+	keys := []string{volumeLockPrefix + volumeID, pathLockPrefix + path}
+	if !d.locks.tryAcquire(keys...) {
+		return nil, newNodeError(codes.Aborted, errorinfo.OperationPending, path, "operation pending for volume %q or path %q", volumeID, path)
+	}
+	return func() { d.locks.release(keys...) }, nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestOperationLocks(t *testing.T) {
+	locks := newOperationLocks()
+
+	require.True(t, locks.tryAcquire("volume:a", "path:/a"))
+	require.False(t, locks.tryAcquire("volume:a", "path:/b"))
+	require.False(t, locks.tryAcquire("volume:b", "path:/a"))
+
+	// A failed acquisition locks none of the keys
+	require.True(t, locks.tryAcquire("volume:b", "path:/b"))
+	locks.release("volume:b", "path:/b")
+
+	locks.release("volume:a", "path:/a")
+	require.True(t, locks.tryAcquire("volume:a", "path:/b"))
+}
+
+func TestOperationPending(t *testing.T) {
+	client, _ := startDriver(t)
+	targetPath := filepath.Join(t.TempDir(), "target-path")
+	publishReq := &csi.NodePublishVolumeRequest{
+		VolumeId:   "volumeID",
+		TargetPath: targetPath,
+		Readonly:   true,
+		VolumeCapability: &csi.VolumeCapability{
+			AccessType: &csi.VolumeCapability_Mount{},
+			AccessMode: &csi.VolumeCapability_AccessMode{},
+		},
+		VolumeContext: map[string]string{
+			"csi.storage.k8s.io/ephemeral": "true",
+		},
+	}
+
+	unlock, err := client.driver.lockOperation("volumeID", "/other/path")
+	require.NoError(t, err)
+
+	// Same volume ID
+	_, err = client.NodePublishVolume(context.Background(), publishReq)
+	requireGRPCStatusPrefix(t, err, codes.Aborted, `operation pending for volume "volumeID"`)
+	require.NoDirExists(t, targetPath)
+
+	// Same target path
+	unlockPath, err := client.driver.lockOperation("otherVolumeID", targetPath)
+	require.NoError(t, err)
+	unlock()
+	_, err = client.NodeUnpublishVolume(context.Background(), &csi.NodeUnpublishVolumeRequest{
+		VolumeId:   "volumeID",
+		TargetPath: targetPath,
+	})
+	requireGRPCStatusPrefix(t, err, codes.Aborted, "operation pending")
+	unlockPath()
+
+	_, err = client.NodePublishVolume(context.Background(), publishReq)
+	require.NoError(t, err)
+	_, err = client.NodeUnpublishVolume(context.Background(), &csi.NodeUnpublishVolumeRequest{
+		VolumeId:   "volumeID",
+		TargetPath: targetPath,
+	})
+	require.NoError(t, err)
+
+	// The locks are released once the operations complete
+	require.Empty(t, client.driver.locks.locks)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/errorinfo"
+)
+
+const (
+	defaultNSMSocketName  = "nsm.io.sock"
+	nsmSocketPollInterval = 200 * time.Millisecond
+)
+
+// nsmSocketOptions are the NSM API socket publishing waits for
+type nsmSocketOptions struct {
+	name        string
+	waitTimeout time.Duration
+}
+
+func newNSMSocketOptions(config *Config) nsmSocketOptions {
+	opts := nsmSocketOptions{
+		name:        config.NSMSocketName,
+		waitTimeout: config.NSMSocketWaitTimeout,
+	}
+	if opts.name == "" {
+		opts.name = defaultNSMSocketName
+	}
+	return opts
+}
+
+// waitNSMSocket waits up to the socket wait timeout for the NSM API socket to accept connections, e.g. while nsmgr
+// starts on node boot. It fails with Unavailable if the socket is not ready in time, as the kubelet retries the RPC
+// instead of starting the pod against a dead socket.
+func (d *Driver) waitNSMSocket(ctx context.Context) error {
+	if d.nsmSocket.waitTimeout == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, d.nsmSocket.waitTimeout)
+	defer cancel()
+
+	socketPath := filepath.Join(d.nsmSocketDir, d.nsmSocket.name)
+	var dialer net.Dialer
+	for {
+		conn, err := dialer.DialContext(ctx, "unix", socketPath)
+		if err == nil {
+			_ = conn.Close()
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return newNodeError(codes.Unavailable, errorinfo.NSMSocketNotReady, socketPath, "NSM API socket %q is not ready: %v", socketPath, err)
+		case <-time.After(nsmSocketPollInterval):
+		}
+	}
+}
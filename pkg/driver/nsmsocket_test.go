@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestNodePublishVolumeWaitNSMSocket(t *testing.T) {
+	for _, tt := range []struct {
+		desc            string
+		listen          func(t *testing.T, socketPath string)
+		timeout         time.Duration
+		expectCode      codes.Code
+		expectMsgPrefix string
+	}{
+		{
+			desc:            "socket missing",
+			timeout:         100 * time.Millisecond,
+			expectCode:      codes.Unavailable,
+			expectMsgPrefix: "NSM API socket",
+		},
+		{
+			desc: "stale socket",
+			listen: func(t *testing.T, socketPath string) {
+				l, err := net.Listen("unix", socketPath)
+				require.NoError(t, err)
+				l.(*net.UnixListener).SetUnlinkOnClose(false)
+				require.NoError(t, l.Close())
+			},
+			timeout:         100 * time.Millisecond,
+			expectCode:      codes.Unavailable,
+			expectMsgPrefix: "NSM API socket",
+		},
+		{
+			desc: "socket ready",
+			listen: func(t *testing.T, socketPath string) {
+				l, err := net.Listen("unix", socketPath)
+				require.NoError(t, err)
+				t.Cleanup(func() { _ = l.Close() })
+			},
+			timeout:    time.Second,
+			expectCode: codes.OK,
+		},
+		{
+			desc: "socket created while waiting",
+			listen: func(t *testing.T, socketPath string) {
+				time.AfterFunc(300*time.Millisecond, func() {
+					if l, err := net.Listen("unix", socketPath); err == nil {
+						t.Cleanup(func() { _ = l.Close() })
+					}
+				})
+			},
+			timeout:    10 * time.Second,
+			expectCode: codes.OK,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			client, nsmSocketDir := startDriver(t, func(config *Config) {
+				config.NSMSocketWaitTimeout = tt.timeout
+			})
+			if tt.listen != nil {
+				tt.listen(t, filepath.Join(nsmSocketDir, defaultNSMSocketName))
+			}
+			targetPath := filepath.Join(t.TempDir(), "target-path")
+
+			_, err := client.NodePublishVolume(context.Background(), &csi.NodePublishVolumeRequest{
+				VolumeId:   "volumeID",
+				TargetPath: targetPath,
+				Readonly:   true,
+				VolumeCapability: &csi.VolumeCapability{
+					AccessType: &csi.VolumeCapability_Mount{},
+					AccessMode: &csi.VolumeCapability_AccessMode{},
+				},
+				VolumeContext: map[string]string{
+					"csi.storage.k8s.io/ephemeral": "true",
+				},
+			})
+			requireGRPCStatusPrefix(t, err, tt.expectCode, tt.expectMsgPrefix)
+			if err == nil {
+				assertMounted(t, client.mounter, targetPath, nsmSocketDir)
+			} else {
+				assertNotMounted(t, client.mounter, targetPath)
+				require.NoDirExists(t, targetPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/errorinfo"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// operations runs the blocking mount, unmount and volume health operations with the RPC deadline, bounded by the
+// operation timeout. The syscalls can't be interrupted, so an operation outliving its deadline is abandoned: it
+// completes in the background, while further operations on the same path fail until it does, so the stuck operations
+// don't pile up.
+type operations struct {
+	logger  log.Logger
+	timeout time.Duration
+
+	mu        sync.Mutex
+	abandoned map[string]string
+}
+
+func newOperations(logger log.Logger, timeout time.Duration) *operations {
+	return &operations{
+		logger:    logger,
+		timeout:   timeout,
+		abandoned: make(map[string]string),
+	}
+}
+
+// run runs op, named name, on path. It fails with DeadlineExceeded if the deadline expires first or if an abandoned
+// operation on path is still running, otherwise it returns the error of op.
+func (o *operations) run(ctx context.Context, name, path string, op func() error) error {
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	if err := o.check(path); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- op() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+
+	o.mu.Lock()
+	o.abandoned[path] = name
+	o.mu.Unlock()
+	o.logger.Warnf("Abandoned %s of %q after %v", name, path, time.Since(start))
+
+	go func() {
+		err := <-done
+		o.mu.Lock()
+		delete(o.abandoned, path)
+		o.mu.Unlock()
+		o.logger.Infof("Abandoned %s of %q completed after %v: %v", name, path, time.Since(start), err)
+	}()
+	return newNodeError(codes.DeadlineExceeded, errorinfo.OperationTimeout, path, "%s of %q did not complete in time: %v", name, path, ctx.Err())
+}
+
+// check fails with DeadlineExceeded if an abandoned operation on path is still running, e.g. before the target path
+// of a pending mount is removed
+func (o *operations) check(path string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if pending, ok := o.abandoned[path]; ok {
+		return newNodeError(codes.DeadlineExceeded, errorinfo.OperationTimeout, path, "abandoned %s of %q is still running", pending, path)
+	}
+	return nil
+}
+
+// count returns the number of abandoned operations still running
+func (o *operations) count() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.abandoned)
+}
+
+// mount bind mounts source to target with the RPC deadline
+func (d *Driver) mount(ctx context.Context, source, target string) error {
+	err := d.ops.run(ctx, "mount", target, func() error {
+		return d.mounter.Mount(source, target)
+	})
+	if err != nil {
+		return fsError(err, errorinfo.MountFailed, target, "unable to mount %q", target)
+	}
+	return nil
+}
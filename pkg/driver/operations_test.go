@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/mount"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// blockingMounter blocks the mounts until unblocked
+type blockingMounter struct {
+	*mount.Fake
+	unblock chan struct{}
+}
+
+func (m *blockingMounter) Mount(source, target string) error {
+	<-m.unblock
+	return m.Fake.Mount(source, target)
+}
+
+func TestOperationTimeout(t *testing.T) {
+	mounter := &blockingMounter{Fake: mount.NewFake(), unblock: make(chan struct{})}
+	client, _ := startDriver(t, func(config *Config) {
+		config.Mounter = mounter
+		config.OperationTimeout = time.Hour
+	})
+	d := client.driver
+	targetPath := filepath.Join(t.TempDir(), "target-path")
+	publish := func(ctx context.Context) error {
+		_, err := client.NodePublishVolume(ctx, &csi.NodePublishVolumeRequest{
+			VolumeId:   "volumeID",
+			TargetPath: targetPath,
+			Readonly:   true,
+			VolumeCapability: &csi.VolumeCapability{
+				AccessType: &csi.VolumeCapability_Mount{},
+				AccessMode: &csi.VolumeCapability_AccessMode{},
+			},
+			VolumeContext: map[string]string{
+				"csi.storage.k8s.io/ephemeral": "true",
+			},
+		})
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	requireGRPCStatusPrefix(t, publish(ctx), codes.DeadlineExceeded, "")
+	require.Eventually(t, func() bool { return d.ops.count() == 1 }, time.Second, 10*time.Millisecond)
+
+	// The abandoned mount blocks the operations on the target path
+	_, err := client.NodeUnpublishVolume(context.Background(), &csi.NodeUnpublishVolumeRequest{
+		VolumeId:   "volumeID",
+		TargetPath: targetPath,
+	})
+	requireGRPCStatusPrefix(t, err, codes.DeadlineExceeded, "abandoned mount of")
+
+	close(mounter.unblock)
+	require.Eventually(t, func() bool { return d.ops.count() == 0 }, time.Second, 10*time.Millisecond)
+	assertMounted(t, mounter.Fake, targetPath, d.nsmSocketDir)
+
+	_, err = client.NodeUnpublishVolume(context.Background(), &csi.NodeUnpublishVolumeRequest{
+		VolumeId:   "volumeID",
+		TargetPath: targetPath,
+	})
+	require.NoError(t, err)
+	require.NoDirExists(t, targetPath)
+}
+
+func TestOperationsRun(t *testing.T) {
+	ops := newOperations(log.L(), 20*time.Millisecond)
+	unblock := make(chan struct{})
+
+	err := ops.run(context.Background(), "health check", "/path", func() error {
+		<-unblock
+		return os.ErrNotExist
+	})
+	requireGRPCStatusPrefix(t, err, codes.DeadlineExceeded, `health check of "/path" did not complete in time`)
+	require.Equal(t, 1, ops.count())
+
+	// Other paths are not blocked
+	require.ErrorIs(t, ops.run(context.Background(), "stat", "/other", func() error { return os.ErrNotExist }), os.ErrNotExist)
+
+	close(unblock)
+	require.Eventually(t, func() bool { return ops.count() == 0 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, ops.run(context.Background(), "stat", "/path", func() error { return nil }))
+}
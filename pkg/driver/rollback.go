@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Steps of the publish and stage operations, named for the rollback logs and the tests injecting failures
+const (
+	stepCreateTargetPath = "create target path"
+	stepSetTargetPath    = "set target path mode and ownership"
+	stepCreateStaging    = "create staging target path"
+	stepMount            = "mount"
+)
+
+// undoStep undoes a completed step
+type undoStep struct {
+	name string
+	undo func() error
+}
+
+// rollback runs the steps of an operation and records how to undo the completed ones, so a failed operation leaves
+// no partial state behind, e.g. the target directory created before a mount failure
+type rollback struct {
+	logger log.Logger
+	// hook is called before each step, the tests inject step failures with it
+	hook  func(step string) error
+	steps []undoStep
+}
+
+// newRollback creates the rollback of an operation
+func (d *Driver) newRollback(logger log.Logger) *rollback {
+	return &rollback{
+		logger: logger,
+		hook:   d.stepHook,
+	}
+}
+
+// do runs the step and, if it succeeds, records undo if not nil
+func (r *rollback) do(name string, step func() error, undo func() error) error {
+	if r.hook != nil {
+		if err := r.hook(name); err != nil {
+			return err
+		}
+	}
+	if err := step(); err != nil {
+		return err
+	}
+	if undo != nil {
+		r.steps = append(r.steps, undoStep{name: name, undo: undo})
+	}
+	return nil
+}
+
+// undo undoes the completed steps in reverse order. A step failing to undo is logged and the rest are undone anyway.
+func (r *rollback) undo() {
+	for i := len(r.steps) - 1; i >= 0; i-- {
+		step := r.steps[i]
+		if err := step.undo(); err != nil {
+			r.logger.Errorf("Failed to roll back %s: %v", step.name, err)
+			continue
+		}
+		r.logger.Debugf("Rolled back %s", step.name)
+	}
+	r.steps = nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+func TestRollback(t *testing.T) {
+	var undone []string
+	steps := &rollback{logger: log.L()}
+	for _, name := range []string{"first", "second", "third"} {
+		require.NoError(t, steps.do(name, func() error { return nil }, func() error {
+			undone = append(undone, name)
+			if name == "second" {
+				return errors.New("undo failed")
+			}
+			return nil
+		}))
+	}
+	require.NoError(t, steps.do("without undo", func() error { return nil }, nil))
+	require.EqualError(t, steps.do("failing", func() error { return errors.New("step failed") }, func() error {
+		t.Fatal("a failed step must not be undone")
+		return nil
+	}), "step failed")
+
+	// The steps are undone in reverse order, even if one of them fails to
+	steps.undo()
+	require.Equal(t, []string{"third", "second", "first"}, undone)
+}
+
+// failStep returns a step hook failing the given step
+func failStep(failing string) func(step string) error {
+	return func(step string) error {
+		if step == failing {
+			return errors.Errorf("injected %s failure", step)
+		}
+		return nil
+	}
+}
+
+func TestNodePublishVolumeRollback(t *testing.T) {
+	client, _ := startDriver(t)
+
+	for _, tt := range []struct {
+		step             string
+		targetPathExists bool
+	}{
+		{step: stepCreateTargetPath},
+		{step: stepSetTargetPath},
+		{step: stepMount},
+		{step: stepMount, targetPathExists: true},
+	} {
+		t.Run(tt.step, func(t *testing.T) {
+			targetPath := filepath.Join(t.TempDir(), "target-path")
+			if tt.targetPathExists {
+				require.NoError(t, os.Mkdir(targetPath, 0o750))
+			}
+			client.driver.stepHook = failStep(tt.step)
+			defer func() { client.driver.stepHook = nil }()
+
+			_, err := client.NodePublishVolume(context.Background(), &csi.NodePublishVolumeRequest{
+				VolumeId:   "volumeID",
+				TargetPath: targetPath,
+				Readonly:   true,
+				VolumeCapability: &csi.VolumeCapability{
+					AccessType: &csi.VolumeCapability_Mount{},
+					AccessMode: &csi.VolumeCapability_AccessMode{},
+				},
+				VolumeContext: map[string]string{
+					"csi.storage.k8s.io/ephemeral": "true",
+				},
+			})
+			requireGRPCStatusPrefix(t, err, codes.Internal, "")
+			require.Contains(t, err.Error(), "injected "+tt.step+" failure")
+
+			assertNotMounted(t, client.mounter, targetPath)
+			if tt.targetPathExists {
+				assert.DirExists(t, targetPath, "the target path created by the kubelet must be kept")
+			} else {
+				assert.NoDirExists(t, targetPath)
+			}
+		})
+	}
+}
+
+func TestNodeStageVolumeRollback(t *testing.T) {
+	client, _ := startDriver(t, func(config *Config) {
+		config.PersistentVolumes = true
+	})
+
+	for _, step := range []string{stepCreateStaging, stepMount} {
+		t.Run(step, func(t *testing.T) {
+			stagingPath := filepath.Join(t.TempDir(), "staging-path")
+			client.driver.stepHook = failStep(step)
+			defer func() { client.driver.stepHook = nil }()
+
+			_, err := client.NodeStageVolume(context.Background(), &csi.NodeStageVolumeRequest{
+				VolumeId:          "volumeID",
+				StagingTargetPath: stagingPath,
+				VolumeCapability: &csi.VolumeCapability{
+					AccessType: &csi.VolumeCapability_Mount{},
+					AccessMode: &csi.VolumeCapability_AccessMode{},
+				},
+			})
+			requireGRPCStatusPrefix(t, err, codes.Internal, "")
+			require.Contains(t, err.Error(), "injected "+step+" failure")
+
+			assertNotMounted(t, client.mounter, stagingPath)
+			assert.NoDirExists(t, stagingPath)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package driver
+
+import (
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// volumeUsage reports the bytes and inodes of the file system the volume path is bind mounted from
+func volumeUsage(volumePath string) ([]*csi.VolumeUsage, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(volumePath, &stat); err != nil {
+		return nil, errors.Wrapf(err, "unable to statfs volume path %s", volumePath)
+	}
+	blockSize := int64(stat.Bsize) //nolint:unconvert // Bsize is int32 on 32-bit platforms
+	return []*csi.VolumeUsage{
+		{
+			Unit:      csi.VolumeUsage_BYTES,
+			Total:     int64(stat.Blocks) * blockSize,
+			Available: int64(stat.Bavail) * blockSize,
+			Used:      int64(stat.Blocks-stat.Bfree) * blockSize,
+		},
+		{
+			Unit:      csi.VolumeUsage_INODES,
+			Total:     int64(stat.Files),
+			Available: int64(stat.Ffree),
+			Used:      int64(stat.Files - stat.Ffree),
+		},
+	}, nil
+}
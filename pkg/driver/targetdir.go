@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// TargetDirModeAttribute is the volume attribute overriding the target directory mode (octal, e.g. "0750")
+	TargetDirModeAttribute = "targetDirMode"
+	// TargetDirUIDAttribute is the volume attribute overriding the target directory owner
+	TargetDirUIDAttribute = "targetDirUID"
+	// TargetDirGIDAttribute is the volume attribute overriding the target directory group
+	TargetDirGIDAttribute = "targetDirGID"
+
+	defaultTargetDirMode os.FileMode = 0o750
+	keepOwnership                    = -1
+)
+
+// targetDirOptions describes how the target path directory is created
+type targetDirOptions struct {
+	mode os.FileMode
+	uid  int
+	gid  int
+}
+
+func newTargetDirOptions(config *Config) targetDirOptions {
+	opts := targetDirOptions{
+		mode: config.TargetDirMode,
+		uid:  keepOwnership,
+		gid:  keepOwnership,
+	}
+	if opts.mode == 0 {
+		opts.mode = defaultTargetDirMode
+	}
+	if config.TargetDirUID != nil {
+		opts.uid = *config.TargetDirUID
+	}
+	if config.TargetDirGID != nil {
+		opts.gid = *config.TargetDirGID
+	}
+	return opts
+}
+
+// withVolumeContext returns the options overridden by the volume attributes
+func (o targetDirOptions) withVolumeContext(volumeContext map[string]string) (targetDirOptions, error) {
+	if value, ok := volumeContext[TargetDirModeAttribute]; ok {
+		mode, err := strconv.ParseUint(value, 8, 32)
+		if err != nil || os.FileMode(mode)&^os.ModePerm != 0 {
+			return o, errors.Errorf("invalid volume attribute %q: %q is not an octal permission mode", TargetDirModeAttribute, value)
+		}
+		o.mode = os.FileMode(mode)
+	}
+	if value, ok := volumeContext[TargetDirUIDAttribute]; ok {
+		uid, err := parseID(value)
+		if err != nil {
+			return o, errors.Errorf("invalid volume attribute %q: %v", TargetDirUIDAttribute, err)
+		}
+		o.uid = uid
+	}
+	if value, ok := volumeContext[TargetDirGIDAttribute]; ok {
+		gid, err := parseID(value)
+		if err != nil {
+			return o, errors.Errorf("invalid volume attribute %q: %v", TargetDirGIDAttribute, err)
+		}
+		o.gid = gid
+	}
+	return o, nil
+}
+
+// create creates the target directory if missing and reports whether it did
+func (o targetDirOptions) create(targetPath string) (bool, error) {
+	if err := os.Mkdir(targetPath, o.mode); err != nil {
+		if os.IsExist(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "unable to create target path %q", targetPath)
+	}
+	return true, nil
+}
+
+// apply applies the mode and ownership to the target directory
+func (o targetDirOptions) apply(targetPath string) error {
+	// Mkdir is subject to umask, so the mode has to be set explicitly
+	if err := os.Chmod(targetPath, o.mode); err != nil {
+		return errors.Wrapf(err, "unable to set mode of target path %q", targetPath)
+	}
+	if o.uid == keepOwnership && o.gid == keepOwnership {
+		return nil
+	}
+	if err := os.Chown(targetPath, o.uid, o.gid); err != nil {
+		return errors.Wrapf(err, "unable to set ownership of target path %q", targetPath)
+	}
+	return nil
+}
+
+func parseID(value string) (int, error) {
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || id > math.MaxInt32 {
+		return 0, errors.Errorf("%q is not a valid ID", value)
+	}
+	return int(id), nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/errorinfo"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/mount"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// unmountOptions are the retries of an unmount failing because the target is busy, e.g. a process of the terminating
+// pod still has the NSM socket open
+type unmountOptions struct {
+	retries  int
+	interval time.Duration
+	lazy     bool
+}
+
+func newUnmountOptions(config *Config) unmountOptions {
+	return unmountOptions{
+		retries:  config.UnmountRetries,
+		interval: config.UnmountRetryInterval,
+		lazy:     config.LazyUnmount,
+	}
+}
+
+// unmount unmounts the top mount of target with the RPC deadline. A busy target is retried with exponential backoff
+// and, if the retries are exhausted and lazy unmount is enabled, detached.
+func (d *Driver) unmount(ctx context.Context, logger log.Logger, target string) error {
+	interval := d.unmountOpts.interval
+	for attempt := 0; ; attempt++ {
+		err := d.ops.run(ctx, "unmount", target, func() error {
+			return d.mounter.Unmount(target)
+		})
+		switch {
+		case err == nil:
+			return nil
+		case !mount.IsBusy(err):
+			return fsError(err, errorinfo.UnmountFailed, target, "unable to unmount %q", target)
+		case attempt == d.unmountOpts.retries:
+			return d.detach(ctx, logger, target, err)
+		}
+
+		logger.Warnf("Target %q is busy, retrying the unmount in %v", target, interval)
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return newNodeError(status.FromContextError(ctx.Err()).Code(), errorinfo.TargetBusy, target,
+				"unable to unmount busy %q in time: %v", target, err)
+		}
+		interval *= 2
+	}
+}
+
+// detach lazily unmounts the busy target if enabled, otherwise it returns the busy error
+func (d *Driver) detach(ctx context.Context, logger log.Logger, target string, busyErr error) error {
+	if !d.unmountOpts.lazy {
+		return fsError(busyErr, errorinfo.UnmountFailed, target, "unable to unmount %q after %d attempts", target, d.unmountOpts.retries+1)
+	}
+
+	logger.Warnf("Target %q is still busy, unmounting it lazily", target)
+	err := d.ops.run(ctx, "lazy unmount", target, func() error {
+		return d.mounter.Detach(target)
+	})
+	if err != nil {
+		return fsError(err, errorinfo.UnmountFailed, target, "unable to lazily unmount %q", target)
+	}
+	return nil
+}
+
+// unmountAll unmounts all the mounts stacked on target, e.g. by a retried publish racing with the kubelet
+func (d *Driver) unmountAll(ctx context.Context, logger log.Logger, target string) error {
+	mountPoints, err := d.mounter.List()
+	if err != nil {
+		return fsError(err, errorinfo.PathFailed, target, "unable to check target path %q", target)
+	}
+
+	// The mount table has the resolved paths, which the kubelet may spell differently
+	paths := map[string]bool{filepath.Clean(target): true}
+	if resolved, err := filepath.EvalSymlinks(target); err == nil {
+		paths[resolved] = true
+	}
+	var layers []string
+	for _, mountPoint := range mountPoints {
+		if paths[filepath.Clean(mountPoint.Target)] {
+			layers = append(layers, mountPoint.Target)
+		}
+	}
+	if len(layers) == 0 {
+		// The mount table may still miss a mount the mounter sees, which is unmounted once
+		mounted, err := d.mounter.IsMountPoint(target)
+		if err != nil {
+			return fsError(err, errorinfo.PathFailed, target, "unable to check target path %q", target)
+		}
+		if mounted {
+			layers = append(layers, target)
+		}
+	}
+	if len(layers) > 1 {
+		logger.Warnf("Unmounting %d mounts stacked on %q", len(layers), target)
+	}
+
+	// The top mount is unmounted first
+	for i := len(layers) - 1; i >= 0; i-- {
+		if err := d.unmount(ctx, logger, layers[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/mount"
+)
+
+func TestUnpublishBusyTarget(t *testing.T) {
+	for _, tt := range []struct {
+		desc            string
+		busy            int
+		lazy            bool
+		timeout         time.Duration
+		expectCode      codes.Code
+		expectMsgPrefix string
+	}{
+		{
+			desc:       "busy until retried",
+			busy:       2,
+			expectCode: codes.OK,
+		},
+		{
+			desc:            "retries exhausted",
+			busy:            -1,
+			expectCode:      codes.Unavailable,
+			expectMsgPrefix: "unable to unmount",
+		},
+		{
+			desc:       "lazy unmount",
+			busy:       -1,
+			lazy:       true,
+			expectCode: codes.OK,
+		},
+		{
+			desc:            "deadline during backoff",
+			busy:            -1,
+			timeout:         50 * time.Millisecond,
+			expectCode:      codes.DeadlineExceeded,
+			expectMsgPrefix: "unable to unmount busy",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			client, nsmSocketDir := startDriver(t, func(config *Config) {
+				config.UnmountRetries = 3
+				config.UnmountRetryInterval = time.Millisecond
+				config.LazyUnmount = tt.lazy
+				if tt.timeout > 0 {
+					config.UnmountRetryInterval = time.Hour
+				}
+			})
+			targetPath := filepath.Join(t.TempDir(), "target-path")
+			require.NoError(t, os.Mkdir(targetPath, 0o750))
+			require.NoError(t, client.mounter.Mount(nsmSocketDir, targetPath))
+			client.mounter.SetBusy(targetPath, tt.busy)
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			// The driver is called directly, so the deadline is seen by the driver rather than by the gRPC client
+			_, err := client.driver.NodeUnpublishVolume(ctx, &csi.NodeUnpublishVolumeRequest{
+				VolumeId:   "volumeID",
+				TargetPath: targetPath,
+			})
+			requireGRPCStatusPrefix(t, err, tt.expectCode, tt.expectMsgPrefix)
+			if err == nil {
+				require.NoDirExists(t, targetPath)
+				assertNotMounted(t, client.mounter, targetPath)
+			} else {
+				assertMounted(t, client.mounter, targetPath, nsmSocketDir)
+			}
+		})
+	}
+}
+
+func TestUnpublishStackedMounts(t *testing.T) {
+	client, nsmSocketDir := startDriver(t)
+	targetPath := filepath.Join(t.TempDir(), "target-path")
+	require.NoError(t, os.Mkdir(targetPath, 0o750))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, client.mounter.Mount(nsmSocketDir, targetPath))
+	}
+
+	_, err := client.NodeUnpublishVolume(context.Background(), &csi.NodeUnpublishVolumeRequest{
+		VolumeId:   "volumeID",
+		TargetPath: targetPath,
+	})
+	require.NoError(t, err)
+	require.NoDirExists(t, targetPath)
+	mountPoints, err := client.mounter.List()
+	require.NoError(t, err)
+	require.Empty(t, mountPoints)
+}
+
+// unlistedMounter misses the mounts in its mount table
+type unlistedMounter struct {
+	*mount.Fake
+}
+
+func (m *unlistedMounter) List() ([]mount.MountPoint, error) {
+	return nil, nil
+}
+
+func TestUnpublishUnlistedTarget(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		mounter func(fake *mount.Fake) mount.Mounter
+		link    bool
+	}{
+		{
+			desc: "target path through a symlink",
+			link: true,
+		},
+		{
+			desc: "mount missing in the mount table",
+			mounter: func(fake *mount.Fake) mount.Mounter {
+				return &unlistedMounter{Fake: fake}
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			fake := mount.NewFake()
+			client, nsmSocketDir := startDriver(t, func(config *Config) {
+				config.Mounter = fake
+				if tt.mounter != nil {
+					config.Mounter = tt.mounter(fake)
+				}
+			})
+
+			// The mount table has the resolved target path, e.g. the kubelet directory is a symlink
+			base := t.TempDir()
+			mountedPath := filepath.Join(base, "kubelet", "target-path")
+			require.NoError(t, os.MkdirAll(mountedPath, 0o750))
+			require.NoError(t, fake.Mount(nsmSocketDir, mountedPath))
+			targetPath := mountedPath
+			if tt.link {
+				require.NoError(t, os.Symlink(filepath.Join(base, "kubelet"), filepath.Join(base, "link")))
+				targetPath = filepath.Join(base, "link", "target-path")
+			}
+
+			_, err := client.NodeUnpublishVolume(context.Background(), &csi.NodeUnpublishVolumeRequest{
+				VolumeId:   "volumeID",
+				TargetPath: targetPath,
+			})
+			require.NoError(t, err)
+			require.NoDirExists(t, mountedPath)
+			assertNotMounted(t, fake, mountedPath)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package errorinfo contains the reasons of the ErrorInfo details of the driver errors. The clients, e.g. the event
+// recorder, match the errors on them, so they must not be changed.
+package errorinfo
+
+// Reasons of the ErrorInfo details of the Node service errors. The errors without a specific reason, e.g. the invalid
+// requests, have the status code as reason, e.g. INVALID_ARGUMENT.
+const (
+	NSMSocketDirMissing   = "NSM_SOCKET_DIR_MISSING"
+	NSMSocketNotReady     = "NSM_SOCKET_NOT_READY"
+	VolumeNotStaged       = "VOLUME_NOT_STAGED"
+	VolumeNotPublished    = "VOLUME_NOT_PUBLISHED"
+	OperationPending      = "OPERATION_PENDING"
+	OperationTimeout      = "OPERATION_TIMEOUT"
+	PathNotFound          = "PATH_NOT_FOUND"
+	InvalidPath           = "INVALID_PATH"
+	PermissionDenied      = "PERMISSION_DENIED"
+	OutOfResources        = "OUT_OF_RESOURCES"
+	TargetBusy            = "TARGET_BUSY"
+	FilesystemUnavailable = "FILESYSTEM_UNAVAILABLE"
+	MountFailed           = "MOUNT_FAILED"
+	UnmountFailed         = "UNMOUNT_FAILED"
+	PathFailed            = "PATH_FAILED"
+)
+
+// Reasons returns all the reasons of the Node service errors
+func Reasons() []string {
+	return []string{
+		NSMSocketDirMissing,
+		NSMSocketNotReady,
+		VolumeNotStaged,
+		VolumeNotPublished,
+		OperationPending,
+		OperationTimeout,
+		PathNotFound,
+		InvalidPath,
+		PermissionDenied,
+		OutOfResources,
+		TargetBusy,
+		FilesystemUnavailable,
+		MountFailed,
+		UnmountFailed,
+		PathFailed,
+	}
+}
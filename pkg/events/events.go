@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package events reports the volume failures as Kubernetes Events on the affected pods, as the kubelet only shows a
+// generic MountVolume.SetUp failure
+package events
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/errorinfo"
+)
+
+// Reasons of the events
+const (
+	ReasonInvalidVolume    = "NSMInvalidVolume"
+	ReasonNSMUnavailable   = "NSMUnavailable"
+	ReasonVolumeNotReady   = "NSMVolumeNotReady"
+	ReasonPermissionDenied = "NSMPermissionDenied"
+	ReasonOutOfResources   = "NSMOutOfResources"
+	ReasonTargetBusy       = "NSMTargetBusy"
+	ReasonPublishFailed    = "NSMPublishFailed"
+)
+
+// failure is the reason and the hint of the event of a publish failure
+type failure struct {
+	reason string
+	hint   string
+}
+
+// publishFailures are the events of the ErrorInfo reasons of the driver errors
+var publishFailures = map[string]failure{
+	errorinfo.NSMSocketDirMissing: {ReasonNSMUnavailable, "NSM socket directory is missing, check that NSM is running on the node"},
+	errorinfo.NSMSocketNotReady:   {ReasonNSMUnavailable, "NSM API socket is not accepting connections, check that NSM is running on the node"},
+	errorinfo.VolumeNotStaged:     {ReasonVolumeNotReady, "NSM volume is not staged on the node"},
+	errorinfo.OperationPending:    {ReasonVolumeNotReady, "Another operation on the NSM volume is pending, the kubelet retries"},
+	errorinfo.OperationTimeout:    {ReasonVolumeNotReady, "An operation on the NSM volume did not complete in time, the kubelet retries"},
+	errorinfo.PathNotFound:        {ReasonVolumeNotReady, "Volume path of the pod is missing on the node"},
+	errorinfo.InvalidPath:         {ReasonVolumeNotReady, "Volume path of the pod is invalid on the node"},
+	errorinfo.PermissionDenied:    {ReasonPermissionDenied, "Permission denied on the volume path, check the privileges of the driver and the SELinux policy of the node"},
+	errorinfo.OutOfResources:      {ReasonOutOfResources, "Node is out of resources, e.g. disk space or file descriptors"},
+	errorinfo.TargetBusy:          {ReasonTargetBusy, "Volume path is busy, a process on the node still uses it"},
+}
+
+// Pod identifies the pod the event is reported on
+type Pod struct {
+	Name      string
+	Namespace string
+	UID       string
+}
+
+// Config is used to create the recorder
+type Config struct {
+	Client kubernetes.Interface
+	// Component is the reporting component of the events, e.g. the plugin name
+	Component string
+	// NodeName is the node the events are reported from
+	NodeName string
+}
+
+// Recorder reports the events of the volume failures through a client-go event broadcaster, which creates them in the
+// background and counts their repeats on the existing events. It only needs to create and patch events, so the RBAC
+// of the driver can be limited to these verbs on the events resource.
+type Recorder struct {
+	broadcaster record.EventBroadcaster
+	recorder    record.EventRecorder
+	nodeName    string
+}
+
+// New creates a new recorder with the given config, it reports the events until Shutdown
+func New(config *Config) (*Recorder, error) {
+	switch {
+	case config.Client == nil:
+		return nil, errors.New("kubernetes client is required")
+	case config.Component == "":
+		return nil, errors.New("component is required")
+	}
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: config.Client.CoreV1().Events("")})
+	return &Recorder{
+		broadcaster: broadcaster,
+		recorder: broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{
+			Component: config.Component,
+			Host:      config.NodeName,
+		}),
+		nodeName: config.NodeName,
+	}, nil
+}
+
+// Shutdown stops reporting the events, the pending ones are dropped
+func (r *Recorder) Shutdown() {
+	r.broadcaster.Shutdown()
+}
+
+// PublishFailed reports the failure to publish the volume of the pod, with a reason and a message telling what to
+// check based on the ErrorInfo reason of err. The event is created in the background, so the failures of the RPC
+// context don't prevent it.
+func (r *Recorder) PublishFailed(pod *Pod, err error) error {
+	if pod.Name == "" || pod.Namespace == "" {
+		return errors.New("pod name and namespace are required, set podInfoOnMount in the CSIDriver object")
+	}
+	st := status.Convert(err)
+	f := publishFailure(st)
+	message := fmt.Sprintf("%s: %s", f.hint, st.Message())
+	if r.nodeName != "" {
+		message = fmt.Sprintf("%s (node %s)", message, r.nodeName)
+	}
+	r.recorder.Event(&corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Name:       pod.Name,
+		Namespace:  pod.Namespace,
+		UID:        types.UID(pod.UID),
+	}, corev1.EventTypeWarning, f.reason, message)
+	return nil
+}
+
+func publishFailure(st *status.Status) failure {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			if f, ok := publishFailures[info.GetReason()]; ok {
+				return f
+			}
+		}
+	}
+	switch st.Code() {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return failure{ReasonInvalidVolume, "Invalid NSM volume, check the csi volume of the pod spec"}
+	default:
+		return failure{ReasonPublishFailed, "Failed to publish the NSM volume"}
+	}
+}
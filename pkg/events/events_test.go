@@ -0,0 +1,235 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/errorinfo"
+)
+
+var testPod = &Pod{Name: "nsc", Namespace: "default", UID: "pod-uid"}
+
+func newTestRecorder(t *testing.T) (*Recorder, *fake.Clientset) {
+	client := fake.NewSimpleClientset()
+	r, err := New(&Config{
+		Client:    client,
+		Component: "csi.networkservicemesh.io",
+		NodeName:  "node",
+	})
+	require.NoError(t, err)
+	t.Cleanup(r.Shutdown)
+	return r, client
+}
+
+func listEvents(t *testing.T, client *fake.Clientset) []corev1.Event {
+	list, err := client.CoreV1().Events(testPod.Namespace).List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	return list.Items
+}
+
+// waitEvents waits for the recorder to report the events in the background until cond holds and returns them
+func waitEvents(t *testing.T, client *fake.Clientset, cond func(events []corev1.Event) bool) []corev1.Event {
+	var events []corev1.Event
+	require.Eventually(t, func() bool {
+		events = listEvents(t, client)
+		return cond(events)
+	}, 5*time.Second, 10*time.Millisecond)
+	return events
+}
+
+// eventCounts returns the counts of the events by their message
+func eventCounts(events []corev1.Event) map[string]int32 {
+	counts := map[string]int32{}
+	for i := range events {
+		counts[events[i].Message] = events[i].Count
+	}
+	return counts
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(&Config{Component: "csi.networkservicemesh.io"})
+	require.EqualError(t, err, "kubernetes client is required")
+
+	_, err = New(&Config{Client: fake.NewSimpleClientset()})
+	require.EqualError(t, err, "component is required")
+}
+
+// errorWithInfo returns a status error with an ErrorInfo detail like the driver errors
+func errorWithInfo(t *testing.T, code codes.Code, reason string) error {
+	st, err := status.New(code, "boom").WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: "csi.networkservicemesh.io"})
+	require.NoError(t, err)
+	return st.Err()
+}
+
+func TestPublishFailed(t *testing.T) {
+	for _, tt := range []struct {
+		desc          string
+		err           func(t *testing.T) error
+		expectReason  string
+		expectMessage string
+	}{
+		{
+			desc:          "invalid argument",
+			err:           func(t *testing.T) error { return errorWithInfo(t, codes.InvalidArgument, "INVALID_ARGUMENT") },
+			expectReason:  ReasonInvalidVolume,
+			expectMessage: "Invalid NSM volume, check the csi volume of the pod spec: boom (node node)",
+		},
+		{
+			desc:          "NSM socket directory missing",
+			err:           func(t *testing.T) error { return errorWithInfo(t, codes.Unavailable, errorinfo.NSMSocketDirMissing) },
+			expectReason:  ReasonNSMUnavailable,
+			expectMessage: "NSM socket directory is missing, check that NSM is running on the node: boom (node node)",
+		},
+		{
+			desc:          "NSM socket not ready",
+			err:           func(t *testing.T) error { return errorWithInfo(t, codes.Unavailable, errorinfo.NSMSocketNotReady) },
+			expectReason:  ReasonNSMUnavailable,
+			expectMessage: "NSM API socket is not accepting connections, check that NSM is running on the node: boom (node node)",
+		},
+		{
+			desc:          "volume not staged",
+			err:           func(t *testing.T) error { return errorWithInfo(t, codes.FailedPrecondition, errorinfo.VolumeNotStaged) },
+			expectReason:  ReasonVolumeNotReady,
+			expectMessage: "NSM volume is not staged on the node: boom (node node)",
+		},
+		{
+			desc:          "permission denied",
+			err:           func(t *testing.T) error { return errorWithInfo(t, codes.PermissionDenied, errorinfo.PermissionDenied) },
+			expectReason:  ReasonPermissionDenied,
+			expectMessage: "Permission denied on the volume path, check the privileges of the driver and the SELinux policy of the node: boom (node node)",
+		},
+		{
+			desc:          "out of resources",
+			err:           func(t *testing.T) error { return errorWithInfo(t, codes.ResourceExhausted, errorinfo.OutOfResources) },
+			expectReason:  ReasonOutOfResources,
+			expectMessage: "Node is out of resources, e.g. disk space or file descriptors: boom (node node)",
+		},
+		{
+			desc:          "target busy",
+			err:           func(t *testing.T) error { return errorWithInfo(t, codes.Unavailable, errorinfo.TargetBusy) },
+			expectReason:  ReasonTargetBusy,
+			expectMessage: "Volume path is busy, a process on the node still uses it: boom (node node)",
+		},
+		{
+			desc:          "unavailable file system",
+			err:           func(t *testing.T) error { return errorWithInfo(t, codes.Unavailable, errorinfo.FilesystemUnavailable) },
+			expectReason:  ReasonPublishFailed,
+			expectMessage: "Failed to publish the NSM volume: boom (node node)",
+		},
+		{
+			desc:          "invalid argument without ErrorInfo",
+			err:           func(*testing.T) error { return status.Error(codes.InvalidArgument, "boom") },
+			expectReason:  ReasonInvalidVolume,
+			expectMessage: "Invalid NSM volume, check the csi volume of the pod spec: boom (node node)",
+		},
+		{
+			desc:          "internal without ErrorInfo",
+			err:           func(*testing.T) error { return status.Error(codes.Internal, "boom") },
+			expectReason:  ReasonPublishFailed,
+			expectMessage: "Failed to publish the NSM volume: boom (node node)",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			r, client := newTestRecorder(t)
+
+			require.NoError(t, r.PublishFailed(testPod, tt.err(t)))
+
+			events := waitEvents(t, client, func(events []corev1.Event) bool { return len(events) == 1 })
+			event := events[0]
+			require.Equal(t, tt.expectReason, event.Reason)
+			require.Equal(t, tt.expectMessage, event.Message)
+			require.Equal(t, corev1.EventTypeWarning, event.Type)
+			require.Equal(t, corev1.ObjectReference{
+				APIVersion: "v1",
+				Kind:       "Pod",
+				Name:       "nsc",
+				Namespace:  "default",
+				UID:        types.UID("pod-uid"),
+			}, event.InvolvedObject)
+			require.Equal(t, corev1.EventSource{Component: "csi.networkservicemesh.io", Host: "node"}, event.Source)
+		})
+	}
+}
+
+func TestPublishFailureReasons(t *testing.T) {
+	// These reasons fall back to the generic event, the others must tell what to check
+	withoutEvent := map[string]bool{
+		errorinfo.VolumeNotPublished:    true,
+		errorinfo.FilesystemUnavailable: true,
+		errorinfo.MountFailed:           true,
+		errorinfo.UnmountFailed:         true,
+		errorinfo.PathFailed:            true,
+	}
+	for _, reason := range errorinfo.Reasons() {
+		f := publishFailure(status.Convert(errorWithInfo(t, codes.Internal, reason)))
+		if withoutEvent[reason] {
+			require.Equal(t, ReasonPublishFailed, f.reason, reason)
+		} else {
+			require.NotEqual(t, ReasonPublishFailed, f.reason, reason)
+		}
+	}
+}
+
+func TestPublishFailedWithoutPodInfo(t *testing.T) {
+	r, client := newTestRecorder(t)
+
+	err := r.PublishFailed(&Pod{UID: "pod-uid"}, status.Error(codes.Internal, "boom"))
+	require.EqualError(t, err, "pod name and namespace are required, set podInfoOnMount in the CSIDriver object")
+	require.Empty(t, listEvents(t, client))
+}
+
+func TestPublishFailedRepeated(t *testing.T) {
+	r, client := newTestRecorder(t)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, r.PublishFailed(testPod, status.Error(codes.Internal, "boom")))
+	}
+	require.NoError(t, r.PublishFailed(testPod, status.Error(codes.Internal, "other")))
+
+	expected := map[string]int32{
+		"Failed to publish the NSM volume: boom (node node)":  3,
+		"Failed to publish the NSM volume: other (node node)": 1,
+	}
+	events := waitEvents(t, client, func(events []corev1.Event) bool {
+		return len(events) == 2 && eventCounts(events)["Failed to publish the NSM volume: boom (node node)"] == 3
+	})
+	require.Equal(t, expected, eventCounts(events))
+}
+
+func TestPublishFailedRepeatedEventDeleted(t *testing.T) {
+	r, client := newTestRecorder(t)
+
+	require.NoError(t, r.PublishFailed(testPod, status.Error(codes.Internal, "boom")))
+	events := waitEvents(t, client, func(events []corev1.Event) bool { return len(events) == 1 })
+	require.NoError(t, client.CoreV1().Events(testPod.Namespace).Delete(context.Background(), events[0].Name, metav1.DeleteOptions{}))
+
+	// The repeat of the expired event creates it again
+	require.NoError(t, r.PublishFailed(testPod, status.Error(codes.Internal, "boom")))
+	waitEvents(t, client, func(events []corev1.Event) bool { return len(events) == 1 })
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logkeys
+
+import (
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// WithRequestFields adds the volume fields set in the CSI request to the logger
+func WithRequestFields(logger log.Logger, req interface{}) log.Logger {
+	if r, ok := req.(interface{ GetName() string }); ok && r.GetName() != "" {
+		logger = logger.WithField(VolumeName, r.GetName())
+	}
+	if r, ok := req.(interface{ GetVolumeId() string }); ok && r.GetVolumeId() != "" {
+		logger = logger.WithField(VolumeID, r.GetVolumeId())
+	}
+	if r, ok := req.(interface{ GetStagingTargetPath() string }); ok && r.GetStagingTargetPath() != "" {
+		logger = logger.WithField(StagingTargetPath, r.GetStagingTargetPath())
+	}
+	if r, ok := req.(interface{ GetTargetPath() string }); ok && r.GetTargetPath() != "" {
+		logger = logger.WithField(TargetPath, r.GetTargetPath())
+	}
+	if r, ok := req.(interface{ GetVolumePath() string }); ok && r.GetVolumePath() != "" {
+		logger = logger.WithField(VolumePath, r.GetVolumePath())
+	}
+	return logger
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2023 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -14,22 +14,41 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package logkeys is used for logging
+// Package logkeys is used for logging. The constants are the field names of the JSON log output, so they must not
+// be changed.
 package logkeys
 
 const (
+	// Code log constant
+	Code = "code"
 	// CSISocketPath log constant
 	CSISocketPath = "csiSocketPath"
+	// Duration log constant
+	Duration = "duration"
 	// FullMethod log constant
 	FullMethod = "fullMethod"
+	// GitCommit log constant
+	GitCommit = "gitCommit"
 	// NodeID log constant
 	NodeID = "nodeID"
+	// Peer log constant
+	Peer = "peer"
+	// PodUID log constant
+	PodUID = "podUID"
+	// RegistrationSocketPath log constant
+	RegistrationSocketPath = "registrationSocketPath"
+	// RequestID log constant
+	RequestID = "requestID"
+	// StagingTargetPath log constant
+	StagingTargetPath = "stagingTargetPath"
 	// TargetPath log constant
 	TargetPath = "targetPath"
 	// Version log constant
 	Version = "version"
 	// VolumeID log constant
 	VolumeID = "volumeID"
+	// VolumeName log constant
+	VolumeName = "volumeName"
 	// VolumePath log constant
 	VolumePath = "volumePath"
 	// NSMSocketDir log constant
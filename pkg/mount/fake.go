@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mount
+
+import (
+	"os"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// Fake is an in-memory mounter. It checks the paths like the node mounter does, but only records the mounts, so the
+// driver can be tested without root.
+type Fake struct {
+	mu          sync.Mutex
+	mountPoints []MountPoint
+	busy        map[string]int
+}
+
+// NewFake returns an in-memory mounter with an empty mount table
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+// Mount records the mount of source to target, both must be existing directories. Like a bind mount, the mount of a
+// mounted source has the root of its top mount.
+func (f *Fake) Mount(source, target string) error {
+	for _, path := range []string{source, target} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return errors.Wrapf(err, "unable to bind mount %s to %s", source, target)
+		}
+		if !info.IsDir() {
+			return errors.Errorf("unable to bind mount %s to %s: %s is not a directory", source, target, path)
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	root := source
+	for _, mountPoint := range f.mountPoints {
+		if mountPoint.Target == source {
+			root = mountPoint.Root
+		}
+	}
+	f.mountPoints = append(f.mountPoints, MountPoint{Root: root, Target: target})
+	return nil
+}
+
+// SetBusy makes the next n unmounts of target fail with EBUSY, all of them if n is negative. Detach ignores it.
+func (f *Fake) SetBusy(target string, n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.busy == nil {
+		f.busy = make(map[string]int)
+	}
+	f.busy[target] = n
+}
+
+// Unmount removes the top mount of target unless it is set busy
+func (f *Fake) Unmount(target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if n := f.busy[target]; n != 0 {
+		if n > 0 {
+			f.busy[target] = n - 1
+		}
+		return errors.Wrapf(syscall.EBUSY, "unable to unmount %s", target)
+	}
+	return f.removeTop(target)
+}
+
+// Detach removes the top mount of target
+func (f *Fake) Detach(target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.removeTop(target)
+}
+
+func (f *Fake) removeTop(target string) error {
+	for i := len(f.mountPoints) - 1; i >= 0; i-- {
+		if f.mountPoints[i].Target == target {
+			f.mountPoints = append(f.mountPoints[:i], f.mountPoints[i+1:]...)
+			return nil
+		}
+	}
+	return errors.Errorf("unable to unmount %s: not mounted", target)
+}
+
+// IsMountPoint checks whether path is mounted on
+func (f *Fake) IsMountPoint(path string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, mountPoint := range f.mountPoints {
+		if mountPoint.Target == path {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// List returns the recorded mounts
+func (f *Fake) List() ([]MountPoint, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]MountPoint(nil), f.mountPoints...), nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mount provides the mounters of the driver: the bind mounts of the node and an in-memory fake to test the
+// driver without root
+package mount
+
+import (
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// MountPoint is a mount of the mount table
+type MountPoint struct {
+	// Root is the path of the mounted directory in its filesystem, the source directory of a bind mount
+	Root string
+	// Target is the path the directory is mounted on
+	Target string
+}
+
+// Mounter mounts the NSM socket directory to the volume paths
+type Mounter interface {
+	// Mount bind mounts source to target read-write, target must be an existing directory
+	Mount(source, target string) error
+	// Unmount unmounts the top mount of target
+	Unmount(target string) error
+	// Detach lazily unmounts the top mount of target: it is detached at once and cleaned up when no longer busy
+	Detach(target string) error
+	// IsMountPoint checks whether path is mounted on
+	IsMountPoint(path string) (bool, error)
+	// List returns the mount table, the mounts stacked on a path are in mount order
+	List() ([]MountPoint, error)
+}
+
+const procMountInfo = "/proc/self/mountinfo"
+
+// New returns the mounter of the node
+func New() Mounter {
+	return &bindMounter{mountInfoPath: procMountInfo}
+}
+
+// bindMounter bind mounts on the node, the mount table is read from mountInfoPath
+type bindMounter struct {
+	mountInfoPath string
+}
+
+// IsMountPoint checks whether path is in the mount table
+func (m *bindMounter) IsMountPoint(path string) (bool, error) {
+	mountPoints, err := m.List()
+	if err != nil {
+		return false, err
+	}
+	for _, mountPoint := range mountPoints {
+		if mountPoint.Target == path {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IsBusy checks whether err is an unmount failure of a busy target
+func IsBusy(err error) bool {
+	return errors.Is(err, syscall.EBUSY)
+}
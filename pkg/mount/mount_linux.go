@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package mount
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// Mount bind mounts source to target. The mount is read-write, so the host can, e.g., manipulate the file attributes
+// for SELinux, while the kubelet mounts the volume read-only into the containers.
+func (m *bindMounter) Mount(source, target string) error {
+	if err := unix.Mount(source, target, "none", unix.MS_BIND, ""); err != nil {
+		return errors.Wrapf(err, "unable to bind mount %s to %s", source, target)
+	}
+	return nil
+}
+
+// Unmount unmounts the top mount of target
+func (m *bindMounter) Unmount(target string) error {
+	if err := unix.Unmount(target, 0); err != nil {
+		return errors.Wrapf(err, "unable to unmount %s", target)
+	}
+	return nil
+}
+
+// Detach lazily unmounts the top mount of target
+func (m *bindMounter) Detach(target string) error {
+	if err := unix.Unmount(target, unix.MNT_DETACH); err != nil {
+		return errors.Wrapf(err, "unable to detach %s", target)
+	}
+	return nil
+}
+
+// List parses the mount table of the process
+func (m *bindMounter) List() ([]MountPoint, error) {
+	file, err := os.Open(filepath.Clean(m.mountInfoPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to open mount info")
+	}
+	defer func() { _ = file.Close() }()
+
+	var mountPoints []MountPoint
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if mountPoint, ok := parseMountInfo(scanner.Text()); ok {
+			mountPoints = append(mountPoints, mountPoint)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "unable to read mount info")
+	}
+	return mountPoints, nil
+}
+
+// parseMountInfo parses a line of /proc/<pid>/mountinfo:
+//
+//	36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+func parseMountInfo(line string) (MountPoint, bool) {
+	const rootField, targetField = 3, 4
+	fields := strings.Fields(line)
+	if len(fields) <= targetField {
+		return MountPoint{}, false
+	}
+	return MountPoint{
+		Root:   unescapeOctal(fields[rootField]),
+		Target: unescapeOctal(fields[targetField]),
+	}, true
+}
+
+// unescapeOctal unescapes the space, tab, newline and backslash characters, escaped as \ooo in the mount info
+func unescapeOctal(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if c, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				sb.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package mount
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-csi-driver/internal/nstest"
+)
+
+func TestBindMounterList(t *testing.T) {
+	m := &bindMounter{mountInfoPath: "testdata/mountinfo"}
+
+	mountPoints, err := m.List()
+	require.NoError(t, err)
+	require.Equal(t, []MountPoint{
+		{Root: "/", Target: "/"},
+		{Root: "/", Target: "/proc"},
+		{Root: "/nsm-socket", Target: "/var/lib/kubelet/pods/8f7e6d5c/volumes/kubernetes.io~csi/nsm-socket/mount"},
+		{Root: "/nsm-socket", Target: "/var/lib/kubelet/pods/with space/volumes/kubernetes.io~csi/nsm-socket/mount"},
+	}, mountPoints)
+
+	ok, err := m.IsMountPoint("/var/lib/kubelet/pods/with space/volumes/kubernetes.io~csi/nsm-socket/mount")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = m.IsMountPoint("/var/lib/kubelet/pods")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = (&bindMounter{mountInfoPath: "testdata/missing"}).List()
+	require.Error(t, err)
+}
+
+func TestBindMounter(t *testing.T) {
+	if !nstest.Run(t) {
+		return
+	}
+
+	m := New()
+	source := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(source, "nsm.io.sock"), nil, 0o600))
+	// The space is escaped in the mount info
+	target := filepath.Join(t.TempDir(), "with space")
+	require.NoError(t, os.Mkdir(target, 0o750))
+
+	ok, err := m.IsMountPoint(target)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, m.Mount(source, target))
+	ok, err = m.IsMountPoint(target)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.FileExists(t, filepath.Join(target, "nsm.io.sock"))
+
+	// Mounts are stacked, unmounting uncovers the previous mount
+	other := t.TempDir()
+	require.NoError(t, m.Mount(other, target))
+	require.NoFileExists(t, filepath.Join(target, "nsm.io.sock"))
+	require.NoError(t, m.Unmount(target))
+	require.FileExists(t, filepath.Join(target, "nsm.io.sock"))
+
+	require.NoError(t, m.Unmount(target))
+	ok, err = m.IsMountPoint(target)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.NoFileExists(t, filepath.Join(target, "nsm.io.sock"))
+
+	require.Error(t, m.Unmount(target))
+	require.Error(t, m.Mount(filepath.Join(source, "missing"), target))
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+
+package mount
+
+import (
+	"github.com/pkg/errors"
+)
+
+var errUnsupported = errors.New("bind mounts are only supported on linux")
+
+// Mount is not supported
+func (m *bindMounter) Mount(_, _ string) error {
+	return errUnsupported
+}
+
+// Unmount is not supported
+func (m *bindMounter) Unmount(_ string) error {
+	return errUnsupported
+}
+
+// Detach is not supported
+func (m *bindMounter) Detach(_ string) error {
+	return errUnsupported
+}
+
+// List is not supported
+func (m *bindMounter) List() ([]MountPoint, error) {
+	return nil, errUnsupported
+}
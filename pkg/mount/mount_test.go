@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mount
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFake(t *testing.T) {
+	source := t.TempDir()
+	target := t.TempDir()
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	f := NewFake()
+
+	require.Error(t, f.Mount(filepath.Join(source, "missing"), target))
+	require.EqualError(t, f.Mount(source, file), "unable to bind mount "+source+" to "+file+": "+file+" is not a directory")
+	require.EqualError(t, f.Unmount(target), "unable to unmount "+target+": not mounted")
+
+	// Mounts stacked on the same target are unmounted in reverse order
+	other := t.TempDir()
+	require.NoError(t, f.Mount(source, target))
+	require.NoError(t, f.Mount(other, target))
+
+	ok, err := f.IsMountPoint(target)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	mountPoints, err := f.List()
+	require.NoError(t, err)
+	require.Equal(t, []MountPoint{{Root: source, Target: target}, {Root: other, Target: target}}, mountPoints)
+
+	require.NoError(t, f.Unmount(target))
+	mountPoints, err = f.List()
+	require.NoError(t, err)
+	require.Equal(t, []MountPoint{{Root: source, Target: target}}, mountPoints)
+
+	require.NoError(t, f.Unmount(target))
+	ok, err = f.IsMountPoint(target)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// A bind mount of a mount point has the root of its top mount
+	require.NoError(t, f.Mount(source, target))
+	bound := t.TempDir()
+	require.NoError(t, f.Mount(target, bound))
+	mountPoints, err = f.List()
+	require.NoError(t, err)
+	require.Equal(t, []MountPoint{{Root: source, Target: target}, {Root: source, Target: bound}}, mountPoints)
+}
+
+func TestFakeBusy(t *testing.T) {
+	target := t.TempDir()
+	f := NewFake()
+	require.NoError(t, f.Mount(t.TempDir(), target))
+	require.NoError(t, f.Mount(t.TempDir(), target))
+
+	f.SetBusy(target, 1)
+	err := f.Unmount(target)
+	require.True(t, IsBusy(err), err)
+	require.NoError(t, f.Unmount(target))
+
+	// Detach ignores the busy target
+	f.SetBusy(target, -1)
+	require.True(t, IsBusy(f.Unmount(target)))
+	require.True(t, IsBusy(f.Unmount(target)))
+	require.NoError(t, f.Detach(target))
+
+	mountPoints, err := f.List()
+	require.NoError(t, err)
+	require.Empty(t, mountPoints)
+	f.SetBusy(target, 0)
+	require.False(t, IsBusy(f.Unmount(target)))
+}
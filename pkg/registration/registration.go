@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registration serves the kubelet plugin registration API, so the driver registers itself with the kubelet
+// without the node-driver-registrar sidecar
+package registration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	registerapi "k8s.io/kubelet/pkg/apis/pluginregistration/v1"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/logkeys"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/unixsocket"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const defaultCheckInterval = 10 * time.Second
+
+// supportedVersions are the CSI versions reported to the kubelet
+var supportedVersions = []string{"1.0.0"}
+
+// Config is used to run the registration server
+type Config struct {
+	Log        log.Logger
+	PluginName string
+	// RegistrationDir is the kubelet plugins_registry directory watched by the kubelet
+	RegistrationDir string
+	// Endpoint is the path of the CSI socket on the host, as seen by the kubelet
+	Endpoint string
+	// CheckInterval is how often the registration socket is checked for removal, 10s if unset
+	CheckInterval time.Duration
+}
+
+// Registrar serves the kubelet plugin registration API
+type Registrar struct {
+	registerapi.UnimplementedRegistrationServer
+
+	logger        log.Logger
+	pluginName    string
+	socketPath    string
+	endpoint      string
+	checkInterval time.Duration
+
+	mu  sync.Mutex
+	err error
+}
+
+// New creates a new registrar with the given config
+func New(config *Config) (*Registrar, error) {
+	switch {
+	case config.PluginName == "":
+		return nil, errors.New("plugin name is required")
+	case config.RegistrationDir == "":
+		return nil, errors.New("registration directory is required")
+	case config.Endpoint == "":
+		return nil, errors.New("kubelet registration path is required")
+	}
+	r := &Registrar{
+		logger:        config.Log,
+		pluginName:    config.PluginName,
+		socketPath:    filepath.Join(config.RegistrationDir, config.PluginName+"-reg.sock"),
+		endpoint:      config.Endpoint,
+		checkInterval: config.CheckInterval,
+	}
+	if r.checkInterval == 0 {
+		r.checkInterval = defaultCheckInterval
+	}
+	return r, nil
+}
+
+// SocketPath returns the path of the registration socket
+func (r *Registrar) SocketPath() string {
+	return r.socketPath
+}
+
+// Run serves the registration API until ctx is done. The kubelet removes the sockets of the plugins_registry
+// directory on restart, so the socket is recreated, and the driver registered again, whenever it is removed.
+func (r *Registrar) Run(ctx context.Context) error {
+	logger := r.logger.WithField(logkeys.RegistrationSocketPath, r.socketPath)
+	for {
+		listener, err := unixsocket.Listen(r.socketPath)
+		if err != nil {
+			r.setErr(err)
+			return err
+		}
+		r.setErr(nil)
+
+		server := grpc.NewServer()
+		registerapi.RegisterRegistrationServer(server, r)
+		serveErrCh := make(chan error, 1)
+		go func() {
+			serveErrCh <- server.Serve(listener)
+		}()
+		logger.Info("Waiting for the kubelet to register the plugin")
+
+		removed := make(chan bool, 1)
+		waitCtx, cancel := context.WithCancel(ctx)
+		go func() {
+			removed <- unixsocket.WaitRemoved(waitCtx, r.socketPath, r.checkInterval)
+		}()
+
+		select {
+		case err = <-serveErrCh:
+			cancel()
+			<-removed
+			r.setErr(errors.Wrap(err, "registration server failed"))
+			return err
+		case ok := <-removed:
+			cancel()
+			server.Stop()
+			if !ok {
+				_ = os.Remove(r.socketPath)
+				return nil
+			}
+			logger.Warn("Registration socket removed, registering again")
+		}
+	}
+}
+
+// Check returns the registration failure reported by the kubelet or the failure to serve the registration API
+func (r *Registrar) Check(_ context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// GetInfo returns the plugin info to the kubelet
+func (r *Registrar) GetInfo(_ context.Context, _ *registerapi.InfoRequest) (*registerapi.PluginInfo, error) {
+	return &registerapi.PluginInfo{
+		Type:              registerapi.CSIPlugin,
+		Name:              r.pluginName,
+		Endpoint:          r.endpoint,
+		SupportedVersions: supportedVersions,
+	}, nil
+}
+
+// NotifyRegistrationStatus receives the registration result from the kubelet
+func (r *Registrar) NotifyRegistrationStatus(_ context.Context, status *registerapi.RegistrationStatus) (*registerapi.RegistrationStatusResponse, error) {
+	if !status.PluginRegistered {
+		err := errors.Errorf("kubelet failed to register the plugin: %s", status.Error)
+		r.logger.Error(err)
+		r.setErr(err)
+		return &registerapi.RegistrationStatusResponse{}, nil
+	}
+	r.logger.Info("Plugin registered with the kubelet")
+	r.setErr(nil)
+	return &registerapi.RegistrationStatusResponse{}, nil
+}
+
+func (r *Registrar) setErr(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
+}
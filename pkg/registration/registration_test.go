@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registration
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	registerapi "k8s.io/kubelet/pkg/apis/pluginregistration/v1"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const testEndpoint = "/var/lib/kubelet/plugins/csi.networkservicemesh.io/csi.sock"
+
+func startRegistrar(t *testing.T) *Registrar {
+	r, err := New(&Config{
+		Log:             log.FromContext(context.Background()),
+		PluginName:      "csi.networkservicemesh.io",
+		RegistrationDir: t.TempDir(),
+		Endpoint:        testEndpoint,
+		CheckInterval:   10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- r.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-errCh)
+		require.NoFileExists(t, r.SocketPath())
+	})
+	return r
+}
+
+func kubeletClient(t *testing.T, r *Registrar) registerapi.RegistrationClient {
+	conn, err := grpc.NewClient("unix://"+r.SocketPath(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return registerapi.NewRegistrationClient(conn)
+}
+
+func requireGetInfo(t *testing.T, r *Registrar) {
+	require.Eventually(t, func() bool {
+		info, err := kubeletClient(t, r).GetInfo(context.Background(), &registerapi.InfoRequest{})
+		return err == nil &&
+			info.Type == registerapi.CSIPlugin &&
+			info.Name == "csi.networkservicemesh.io" &&
+			info.Endpoint == testEndpoint
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(&Config{PluginName: "csi.networkservicemesh.io", RegistrationDir: t.TempDir()})
+	require.EqualError(t, err, "kubelet registration path is required")
+}
+
+func TestRegistration(t *testing.T) {
+	r := startRegistrar(t)
+	requireGetInfo(t, r)
+
+	_, err := kubeletClient(t, r).NotifyRegistrationStatus(context.Background(), &registerapi.RegistrationStatus{
+		PluginRegistered: false,
+		Error:            "plugin already registered",
+	})
+	require.NoError(t, err)
+	require.EqualError(t, r.Check(context.Background()), "kubelet failed to register the plugin: plugin already registered")
+
+	_, err = kubeletClient(t, r).NotifyRegistrationStatus(context.Background(), &registerapi.RegistrationStatus{
+		PluginRegistered: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, r.Check(context.Background()))
+}
+
+func TestRegistrationSocketRemoved(t *testing.T) {
+	r := startRegistrar(t)
+	requireGetInfo(t, r)
+
+	require.NoError(t, os.Remove(r.SocketPath()))
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(r.SocketPath())
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	requireGetInfo(t, r)
+}
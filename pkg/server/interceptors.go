@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"path"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/logkeys"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// RequestIDHeader is the metadata key carrying the request ID, a request ID sent by the client is reused
+	RequestIDHeader = "x-request-id"
+
+	requestIDSize = 8
+)
+
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the request ID of the RPC
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// withRequestID stores the request ID in ctx and adds it to the logger of ctx
+func withRequestID(ctx context.Context, logger log.Logger) context.Context {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDHeader); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	if id == "" {
+		id = newRequestID()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, id))
+
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	return log.WithLog(ctx, logger.WithField(logkeys.RequestID, id))
+}
+
+func newRequestID() string {
+	b := make([]byte, requestIDSize)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+type requestIDInterceptor struct {
+	Log log.Logger
+}
+
+// UnaryRequestID assigns a request ID to the RPC and propagates it in the logger of the RPC context
+func (i requestIDInterceptor) UnaryRequestID(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(withRequestID(ctx, i.Log), req)
+}
+
+// StreamRequestID assigns a request ID to the RPC and propagates it in the logger of the RPC context
+func (i requestIDInterceptor) StreamRequestID(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: withRequestID(ss.Context(), i.Log)})
+}
+
+// UnaryRecovery converts a panic of the handler into an Internal error, so it doesn't kill the node plugin
+func UnaryRecovery(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
+	defer recoverPanic(ctx, info.FullMethod, &err)
+	return handler(ctx, req)
+}
+
+// StreamRecovery converts a panic of the handler into an Internal error, so it doesn't kill the node plugin
+func StreamRecovery(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer recoverPanic(ss.Context(), info.FullMethod, &err)
+	return handler(srv, ss)
+}
+
+func recoverPanic(ctx context.Context, fullMethod string, err *error) {
+	if r := recover(); r != nil {
+		log.FromContext(ctx).WithField(logkeys.FullMethod, fullMethod).Errorf("RPC panicked: %v\n%s", r, debug.Stack())
+		*err = status.Errorf(codes.Internal, "panic in %s: %v", fullMethod, r)
+	}
+}
+
+type timeoutInterceptor struct {
+	defaultTimeout time.Duration
+	methodTimeouts map[string]time.Duration
+}
+
+// timeout returns the timeout of the method, looked up by the full method name or by the method name only
+func (i timeoutInterceptor) timeout(fullMethod string) time.Duration {
+	if timeout, ok := i.methodTimeouts[fullMethod]; ok {
+		return timeout
+	}
+	if timeout, ok := i.methodTimeouts[path.Base(fullMethod)]; ok {
+		return timeout
+	}
+	return i.defaultTimeout
+}
+
+// UnaryTimeout sets the deadline of the method on the RPC context, an earlier deadline of the client is kept
+func (i timeoutInterceptor) UnaryTimeout(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if timeout := i.timeout(info.FullMethod); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return handler(ctx, req)
+}
+
+// contextServerStream replaces the context of a server stream
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
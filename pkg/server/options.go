@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type serverOptions struct {
+	log                log.Logger
+	driver             Driver
+	controller         csi.ControllerServer
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+	grpcOptions        []grpc.ServerOption
+	defaultTimeout     time.Duration
+	methodTimeouts     map[string]time.Duration
+}
+
+// Option is an option for the CSI grpc server
+type Option func(o *serverOptions)
+
+// WithLog sets the logger of the server
+func WithLog(logger log.Logger) Option {
+	return func(o *serverOptions) {
+		o.log = logger
+	}
+}
+
+// WithDriver sets the CSI driver serving the identity and node services, it is required
+func WithDriver(driver Driver) Option {
+	return func(o *serverOptions) {
+		o.driver = driver
+	}
+}
+
+// WithController sets an optional CSI controller service
+func WithController(controller csi.ControllerServer) Option {
+	return func(o *serverOptions) {
+		o.controller = controller
+	}
+}
+
+// WithDefaultTimeout sets the deadline of the RPCs without a method timeout, 0 disables it
+func WithDefaultTimeout(timeout time.Duration) Option {
+	return func(o *serverOptions) {
+		o.defaultTimeout = timeout
+	}
+}
+
+// WithMethodTimeouts sets the deadlines of RPCs by method, keyed by the method name (e.g. "NodePublishVolume") or
+// the full method name (e.g. "/csi.v1.Node/NodePublishVolume")
+func WithMethodTimeouts(timeouts map[string]time.Duration) Option {
+	return func(o *serverOptions) {
+		if o.methodTimeouts == nil {
+			o.methodTimeouts = make(map[string]time.Duration, len(timeouts))
+		}
+		for method, timeout := range timeouts {
+			o.methodTimeouts[method] = timeout
+		}
+	}
+}
+
+// WithUnaryInterceptors adds unary interceptors called after the built-in ones
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) Option {
+	return func(o *serverOptions) {
+		o.unaryInterceptors = append(o.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors adds stream interceptors called after the built-in ones
+func WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) Option {
+	return func(o *serverOptions) {
+		o.streamInterceptors = append(o.streamInterceptors, interceptors...)
+	}
+}
+
+// WithServerOptions adds grpc server options, e.g. credentials or keepalive parameters
+func WithServerOptions(opts ...grpc.ServerOption) Option {
+	return func(o *serverOptions) {
+		o.grpcOptions = append(o.grpcOptions, opts...)
+	}
+}
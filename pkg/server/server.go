@@ -1,4 +1,4 @@
-// Copyright (c) 2023 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,23 +20,36 @@ package server
 import (
 	"context"
 	"net"
-	"os"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
 
 	"github.com/networkservicemesh/cmd-csi-driver/pkg/logkeys"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/unixsocket"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
+const defaultSocketCheckInterval = 5 * time.Second
+
 // Config is used to run grpc server
 type Config struct {
 	Log           log.Logger
 	CSISocketPath string
 	Driver        Driver
+	// Controller is an optional CSI controller service, registered if not nil
+	Controller csi.ControllerServer
+	// SocketCheckInterval is how often the CSI socket is checked for removal, 5s if unset
+	SocketCheckInterval time.Duration
+	// DefaultTimeout is the deadline of the RPCs without a method timeout, 0 disables it
+	DefaultTimeout time.Duration
+	// MethodTimeouts are the deadlines of RPCs by method name
+	MethodTimeouts map[string]time.Duration
 }
 
 // Driver is a CSI driver interface
@@ -45,58 +58,185 @@ type Driver interface {
 	csi.NodeServer
 }
 
-// Run starts grpc server
-func Run(config Config) error {
+// Run starts grpc server on the CSI socket
+func Run(config Config, opts ...Option) error {
 	if config.CSISocketPath == "" {
 		return errors.New("CSI socket path is required")
 	}
+	if config.SocketCheckInterval == 0 {
+		config.SocketCheckInterval = defaultSocketCheckInterval
+	}
 
-	if err := os.Remove(config.CSISocketPath); err != nil && !os.IsNotExist(err) {
-		config.Log.Error(err, "Unable to remove CSI socket")
+	opts = append([]Option{
+		WithLog(config.Log),
+		WithDriver(config.Driver),
+		WithController(config.Controller),
+		WithDefaultTimeout(config.DefaultTimeout),
+		WithMethodTimeouts(config.MethodTimeouts),
+	}, opts...)
+	server, err := newServer(opts...)
+	if err != nil {
+		return err
 	}
 
-	listener, err := net.Listen("unix", config.CSISocketPath)
+	return serveUnixSocket(context.Background(), server, &config)
+}
+
+// Serve serves the CSI services on the listener until ctx is done. It allows embedding the driver into other
+// commands or serving on a listener passed by socket activation.
+func Serve(ctx context.Context, listener net.Listener, opts ...Option) error {
+	server, err := newServer(opts...)
 	if err != nil {
-		return errors.Errorf("unable to create CSI socket listener: %v", err)
+		return err
 	}
 
-	rpcLogger := rpcLogger{Log: config.Log}
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+			server.Stop()
+		case <-stopped:
+		}
+	}()
+
+	if err := server.Serve(listener); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
 
-	server := grpc.NewServer(
-		grpc.UnaryInterceptor(rpcLogger.UnaryRPCLogger),
-		grpc.StreamInterceptor(rpcLogger.StreamRPCLogger),
-	)
-	csi.RegisterIdentityServer(server, config.Driver)
-	csi.RegisterNodeServer(server, config.Driver)
+func newServer(opts ...Option) (*grpc.Server, error) {
+	o := &serverOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.driver == nil {
+		return nil, errors.New("CSI driver is required")
+	}
+	if o.log == nil {
+		o.log = log.L()
+	}
 
-	config.Log.Info("Listening...")
-	return server.Serve(listener)
+	requestID := requestIDInterceptor{Log: o.log}
+	rpcLogger := rpcLogger{}
+	timeout := timeoutInterceptor{defaultTimeout: o.defaultTimeout, methodTimeouts: o.methodTimeouts}
+
+	// The request ID comes first so every other interceptor logs with it, the recovery wraps everything below it,
+	// and the interceptors of the caller run last with the RPC deadline already set.
+	unaryInterceptors := append([]grpc.UnaryServerInterceptor{
+		requestID.UnaryRequestID,
+		rpcLogger.UnaryRPCLogger,
+		UnaryRecovery,
+		timeout.UnaryTimeout,
+	}, o.unaryInterceptors...)
+	streamInterceptors := append([]grpc.StreamServerInterceptor{
+		requestID.StreamRequestID,
+		rpcLogger.StreamRPCLogger,
+		StreamRecovery,
+	}, o.streamInterceptors...)
+
+	grpcOptions := append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	}, o.grpcOptions...)
+
+	server := grpc.NewServer(grpcOptions...)
+	csi.RegisterIdentityServer(server, o.driver)
+	csi.RegisterNodeServer(server, o.driver)
+	if o.controller != nil {
+		csi.RegisterControllerServer(server, o.controller)
+	}
+	return server, nil
 }
 
-type rpcLogger struct {
-	Log log.Logger
+// serveUnixSocket serves on the CSI socket until ctx is done. If the socket file is removed, the kubelet can no
+// longer reach the server, so the listener is recreated on a fresh socket.
+func serveUnixSocket(ctx context.Context, server *grpc.Server, config *Config) error {
+	logger := config.Log.WithField(logkeys.CSISocketPath, config.CSISocketPath)
+	for {
+		listener, err := unixsocket.Listen(config.CSISocketPath)
+		if err != nil {
+			return errors.Errorf("unable to create CSI socket listener: %v", err)
+		}
+
+		serveErrCh := make(chan error, 1)
+		go func() {
+			serveErrCh <- server.Serve(listener)
+		}()
+		logger.Info("Listening...")
+
+		removedCh := make(chan bool, 1)
+		watchCtx, cancel := context.WithCancel(ctx)
+		go func() {
+			removedCh <- unixsocket.WaitRemoved(watchCtx, config.CSISocketPath, config.SocketCheckInterval)
+		}()
+
+		select {
+		case err := <-serveErrCh:
+			cancel()
+			<-removedCh
+			return err
+		case removed := <-removedCh:
+			cancel()
+			if !removed {
+				server.Stop()
+				<-serveErrCh
+				return nil
+			}
+			// Connections accepted on the old socket are still served, only the listener is replaced
+			logger.Warn("CSI socket removed, listening on a new socket")
+			_ = listener.Close()
+			<-serveErrCh
+		}
+	}
 }
 
-// UnaryRPCLogger is used for logging
+type rpcLogger struct{}
+
+// UnaryRPCLogger logs every RPC with its duration, status code, peer and the volume fields of the request. The
+// logger is propagated through the RPC context, so the driver logs with the same fields.
 func (l rpcLogger) UnaryRPCLogger(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	logger := l.Log.WithField(logkeys.FullMethod, info.FullMethod)
-	resp, err := handler(ctx, req)
-	if err != nil {
-		logger.Error(err, "RPC failed")
-	} else {
-		logger.Info("RPC succeeded")
-	}
+	start := time.Now()
+	logger := logkeys.WithRequestFields(log.FromContext(ctx), req).
+		WithField(logkeys.FullMethod, info.FullMethod).
+		WithField(logkeys.Peer, peerAddr(ctx))
+	resp, err := handler(log.WithLog(ctx, logger), req)
+	logRPC(logger, start, err)
 	return resp, err
 }
 
-// StreamRPCLogger is used for logging
+// StreamRPCLogger logs every RPC with its duration, status code and peer
 func (l rpcLogger) StreamRPCLogger(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-	logger := l.Log.WithField(logkeys.FullMethod, info.FullMethod)
-	err := handler(srv, ss)
+	start := time.Now()
+	logger := log.FromContext(ss.Context()).
+		WithField(logkeys.FullMethod, info.FullMethod).
+		WithField(logkeys.Peer, peerAddr(ss.Context()))
+	err := handler(srv, &contextServerStream{ServerStream: ss, ctx: log.WithLog(ss.Context(), logger)})
+	logRPC(logger, start, err)
+	return err
+}
+
+// logRPC logs the completed RPC. Successful RPCs are logged at debug level, as the kubelet polls some of them, e.g.
+// NodeGetVolumeStats, and the driver logs the volume changes itself.
+func logRPC(logger log.Logger, start time.Time, err error) {
+	logger = logger.
+		WithField(logkeys.Duration, time.Since(start).String()).
+		WithField(logkeys.Code, status.Code(err).String())
 	if err != nil {
 		logger.Error(err, "RPC failed")
 	} else {
-		logger.Info("RPC succeeded")
+		logger.Debug("RPC succeeded")
 	}
-	return err
+}
+
+func peerAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if addr := p.Addr.String(); addr != "" {
+		return p.Addr.Network() + ":" + addr
+	}
+	return p.Addr.Network()
 }
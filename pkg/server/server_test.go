@@ -0,0 +1,283 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/cmd-csi-driver/internal/logging"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/driver"
+	"github.com/networkservicemesh/cmd-csi-driver/pkg/logkeys"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+func testConfig(t *testing.T) *Config {
+	logger := log.FromContext(context.Background())
+	d, err := driver.New(&driver.Config{
+		Log:          logger,
+		NodeID:       "nodeID",
+		PluginName:   "csi.networkservicemesh.io",
+		Version:      "v1.0.0",
+		NSMSocketDir: t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	return &Config{
+		Log:                 logger,
+		CSISocketPath:       filepath.Join(t.TempDir(), "csi.sock"),
+		Driver:              d,
+		SocketCheckInterval: 10 * time.Millisecond,
+	}
+}
+
+func requirePluginInfo(t *testing.T, socketPath string) {
+	conn, err := grpc.NewClient("unix://"+socketPath, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	require.Eventually(t, func() bool {
+		resp, err := csi.NewIdentityClient(conn).GetPluginInfo(context.Background(), &csi.GetPluginInfoRequest{})
+		return err == nil && resp.GetName() == "csi.networkservicemesh.io"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestServeRecreatesRemovedSocket(t *testing.T) {
+	config := testConfig(t)
+
+	server := grpc.NewServer()
+	csi.RegisterIdentityServer(server, config.Driver)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- serveUnixSocket(ctx, server, config)
+	}()
+
+	requirePluginInfo(t, config.CSISocketPath)
+
+	require.NoError(t, os.Remove(config.CSISocketPath))
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(config.CSISocketPath)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	requirePluginInfo(t, config.CSISocketPath)
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
+
+func TestServe(t *testing.T) {
+	config := testConfig(t)
+
+	listener, err := net.Listen("unix", config.CSISocketPath)
+	require.NoError(t, err)
+
+	var intercepted []string
+	interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		intercepted = append(intercepted, info.FullMethod)
+		return handler(ctx, req)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Serve(ctx, listener,
+			WithLog(config.Log),
+			WithDriver(config.Driver),
+			WithUnaryInterceptors(interceptor),
+			WithServerOptions(grpc.MaxRecvMsgSize(1024*1024)),
+		)
+	}()
+
+	requirePluginInfo(t, config.CSISocketPath)
+	require.Equal(t, []string{"/csi.v1.Identity/GetPluginInfo"}, intercepted)
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
+
+func TestServeRequiresDriver(t *testing.T) {
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "csi.sock"))
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+
+	require.EqualError(t, Serve(context.Background(), listener), "CSI driver is required")
+}
+
+type panickingDriver struct {
+	Driver
+}
+
+func (d *panickingDriver) Probe(context.Context, *csi.ProbeRequest) (*csi.ProbeResponse, error) {
+	panic("probe exploded")
+}
+
+func serveTest(t *testing.T, config *Config, opts ...Option) csi.IdentityClient {
+	listener, err := net.Listen("unix", config.CSISocketPath)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Serve(ctx, listener, append([]Option{WithLog(config.Log), WithDriver(config.Driver)}, opts...)...)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-errCh)
+	})
+
+	conn, err := grpc.NewClient("unix://"+config.CSISocketPath, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return csi.NewIdentityClient(conn)
+}
+
+func TestInterceptors(t *testing.T) {
+	config := testConfig(t)
+	config.Driver = &panickingDriver{Driver: config.Driver}
+
+	var deadline time.Time
+	var requestID string
+	client := serveTest(t, config,
+		WithDefaultTimeout(time.Hour),
+		WithMethodTimeouts(map[string]time.Duration{"GetPluginInfo": time.Minute}),
+		WithUnaryInterceptors(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			deadline, _ = ctx.Deadline()
+			requestID = RequestIDFromContext(ctx)
+			return handler(ctx, req)
+		}),
+	)
+
+	t.Run("panic recovery", func(t *testing.T) {
+		_, err := client.Probe(context.Background(), &csi.ProbeRequest{})
+		require.Equal(t, codes.Internal, status.Code(err))
+		require.Contains(t, status.Convert(err).Message(), "probe exploded")
+
+		// The server keeps serving after the panic
+		_, err = client.GetPluginInfo(context.Background(), &csi.GetPluginInfoRequest{})
+		require.NoError(t, err)
+	})
+
+	t.Run("method timeout", func(t *testing.T) {
+		_, err := client.GetPluginInfo(context.Background(), &csi.GetPluginInfoRequest{})
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+
+		_, err = client.GetPluginCapabilities(context.Background(), &csi.GetPluginCapabilitiesRequest{})
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now().Add(time.Hour), deadline, 5*time.Second)
+	})
+
+	t.Run("request ID", func(t *testing.T) {
+		var header metadata.MD
+		_, err := client.GetPluginInfo(context.Background(), &csi.GetPluginInfoRequest{}, grpc.Header(&header))
+		require.NoError(t, err)
+		require.NotEmpty(t, requestID)
+		require.Equal(t, []string{requestID}, header.Get(RequestIDHeader))
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), RequestIDHeader, "client-request-id")
+		_, err = client.GetPluginInfo(ctx, &csi.GetPluginInfoRequest{})
+		require.NoError(t, err)
+		require.Equal(t, "client-request-id", requestID)
+	})
+}
+
+// syncBuffer is a log output written by the server goroutines and read by the test
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// lines returns the JSON log lines
+func (b *syncBuffer) lines(t *testing.T) []map[string]interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var lines []map[string]interface{}
+	for _, line := range bytes.Split(bytes.TrimSpace(b.buf.Bytes()), []byte("\n")) {
+		fields := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(line, &fields))
+		lines = append(lines, fields)
+	}
+	return lines
+}
+
+func TestRPCLogger(t *testing.T) {
+	out := &syncBuffer{}
+	logger, _, err := logging.New(out, "DEBUG", logging.FormatJSON)
+	require.NoError(t, err)
+	config := testConfig(t)
+	config.Log = logger
+	serveTest(t, config)
+
+	conn, err := grpc.NewClient("unix://"+config.CSISocketPath, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	targetPath := filepath.Join(t.TempDir(), "target-path")
+	_, err = csi.NewNodeClient(conn).NodeUnpublishVolume(context.Background(), &csi.NodeUnpublishVolumeRequest{
+		VolumeId:   "volumeID",
+		TargetPath: targetPath,
+	})
+	require.NoError(t, err)
+
+	var driverLine, rpcLine map[string]interface{}
+	for _, line := range out.lines(t) {
+		switch line["msg"] {
+		case "Volume already unpublished":
+			driverLine = line
+		case "RPC succeeded":
+			rpcLine = line
+		}
+	}
+	require.NotNil(t, driverLine, "missing driver log line")
+	require.NotNil(t, rpcLine, "missing RPC log line")
+
+	require.NotEmpty(t, rpcLine[logkeys.RequestID])
+	require.Equal(t, "/csi.v1.Node/NodeUnpublishVolume", rpcLine[logkeys.FullMethod])
+	require.Equal(t, codes.OK.String(), rpcLine[logkeys.Code])
+	require.NotEmpty(t, rpcLine[logkeys.Duration])
+	require.Contains(t, rpcLine[logkeys.Peer], "unix")
+	require.Equal(t, "volumeID", rpcLine[logkeys.VolumeID])
+	require.Equal(t, targetPath, rpcLine[logkeys.TargetPath])
+
+	// The driver logs with the logger of the RPC
+	require.Equal(t, rpcLine[logkeys.RequestID], driverLine[logkeys.RequestID])
+	require.Equal(t, "volumeID", driverLine[logkeys.VolumeID])
+	require.Equal(t, targetPath, driverLine[logkeys.TargetPath])
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package unixsocket
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	listenPIDEnv     = "LISTEN_PID"
+	listenFDsEnv     = "LISTEN_FDS"
+	listenFDNamesEnv = "LISTEN_FDNAMES"
+	// listenFDsStart is the first file descriptor passed by socket activation
+	listenFDsStart = 3
+)
+
+// ActivationListeners returns the listeners passed to the process by systemd-style socket activation, or nil if the
+// process is not socket activated. The activation environment is cleared, so it is not inherited by child processes.
+func ActivationListeners() ([]net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv(listenPIDEnv))
+	if err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+	defer func() {
+		_ = os.Unsetenv(listenPIDEnv)
+		_ = os.Unsetenv(listenFDsEnv)
+		_ = os.Unsetenv(listenFDNamesEnv)
+	}()
+
+	count, err := strconv.Atoi(os.Getenv(listenFDsEnv))
+	if err != nil || count < 0 {
+		return nil, errors.Errorf("invalid %s: %q", listenFDsEnv, os.Getenv(listenFDsEnv))
+	}
+
+	listeners := make([]net.Listener, 0, count)
+	for fd := listenFDsStart; fd < listenFDsStart+count; fd++ {
+		syscall.CloseOnExec(fd)
+		file := os.NewFile(uintptr(fd), "listen-fd-"+strconv.Itoa(fd))
+		listener, err := net.FileListener(file)
+		_ = file.Close()
+		if err != nil {
+			for _, l := range listeners {
+				_ = l.Close()
+			}
+			return nil, errors.Wrapf(err, "file descriptor %d is not a listening socket", fd)
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners, nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package unixsocket is used to listen on Unix domain sockets and to watch the socket files
+package unixsocket
+
+import (
+	"context"
+	"net"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Listen removes a stale socket file at path and listens on a new one
+func Listen(path string) (net.Listener, error) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "unable to remove socket %q", path)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to listen on socket %q", path)
+	}
+	return listener, nil
+}
+
+// WaitRemoved blocks until the socket file at path is removed or replaced by another file, checking it every
+// interval. It returns true if the socket is gone and false if ctx is done first.
+func WaitRemoved(ctx context.Context, path string, interval time.Duration) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return true
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+			current, err := os.Stat(path)
+			if err != nil || !os.SameFile(info, current) {
+				return true
+			}
+		}
+	}
+}